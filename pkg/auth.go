@@ -0,0 +1,70 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/key"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/ping"
+)
+
+// DetectAPIKeyType finds out whether apiKey belongs to a paid plan or to the Demo plan.
+// Paid keys are recognized by the /key endpoint, whose response is returned;
+// Demo keys are verified with /ping and return a nil key response.
+func DetectAPIKeyType(ctx context.Context, apiKey string, options ...ClientOption) (base.APIKeyType, *key.KeyResponse, error) {
+	config := base.DefaultConfig()
+	config.RetryPolicy = base.NoRetryPolicy()
+
+	proClient := base.NewBaseClient(config, withOption(options, base.WithProAPIKey(apiKey))...)
+	response, err := key.NewClient(proClient).KeyWithContext(ctx)
+	if err == nil {
+		return base.APIKeyTypePro, response, nil
+	}
+	if !base.IsUnauthorized(err) {
+		return "", nil, fmt.Errorf("failed to detect API key type: %w", err)
+	}
+
+	demoClient := base.NewBaseClient(config, withOption(options, base.WithDemoAPIKey(apiKey))...)
+	if _, err := ping.NewClient(demoClient).PingWithContext(ctx); err != nil {
+		return "", nil, fmt.Errorf("failed to detect API key type: %w", err)
+	}
+
+	return base.APIKeyTypeDemo, nil, nil
+}
+
+// NewClientWithDetectedAPIKey creates a client authenticated with apiKey after detecting
+// its plan, and sets the client-side rate limit to the rate allowed by that plan
+// unless a rate limit is set with WithRateLimit
+func NewClientWithDetectedAPIKey(ctx context.Context, apiKey string, options ...ClientOption) (Client, error) {
+	keyType, response, err := DetectAPIKeyType(ctx, apiKey, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var client *ClientImpl
+	requestsPerMinute := base.DemoPlanRateLimit
+	if keyType == base.APIKeyTypePro {
+		client = newClientImpl(base.DefaultConfig(), withOption(options, base.WithProAPIKey(apiKey))...)
+		requestsPerMinute = response.RateLimitRequestPerMinute
+		if requestsPerMinute <= 0 {
+			requestsPerMinute = base.PlanRateLimit(response.Plan)
+		}
+	} else {
+		client = newClientImpl(base.DefaultConfig(), withOption(options, base.WithDemoAPIKey(apiKey))...)
+	}
+
+	// A rate limit chosen by the caller takes precedence over the plan limit
+	if requestsPerMinute > 0 && client.baseClient.RateLimiter().RequestsPerMinute() == 0 {
+		client.baseClient.SetRateLimit(requestsPerMinute)
+	}
+
+	return client, nil
+}
+
+// withOption returns a copy of options with option appended, leaving the caller's slice untouched
+func withOption(options []ClientOption, option ClientOption) []ClientOption {
+	result := make([]ClientOption, 0, len(options)+1)
+	result = append(result, options...)
+	return append(result, option)
+}
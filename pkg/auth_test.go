@@ -0,0 +1,79 @@
+package pkg_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ipangpang/coingecko-v3/pkg"
+	"github.com/ipangpang/coingecko-v3/pkg/coingeckotest"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/key"
+)
+
+// newKeyServer returns a fake API accepting proKey on /key, like a paid plan
+func newKeyServer(t *testing.T, proKey string) *coingeckotest.Server {
+	t.Helper()
+
+	server := coingeckotest.NewServer()
+	t.Cleanup(server.Close)
+
+	fixture := server.Fixture(key.KeyEndpoint)
+	server.Handle(key.KeyEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(base.ProAPIKeyHeader) != proKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"status":{"error_code":10010,"error_message":"Invalid API key"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(fixture)
+	})
+	return server
+}
+
+func TestNewClientWithDetectedAPIKeyRateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		apiKey  string
+		options []pkg.ClientOption
+		want    int
+	}{
+		{
+			name:   "pro plan limit",
+			apiKey: "pro-key",
+			want:   500,
+		},
+		{
+			name:   "demo plan limit",
+			apiKey: "demo-key",
+			want:   base.DemoPlanRateLimit,
+		},
+		{
+			name:    "explicit limit on a pro key",
+			apiKey:  "pro-key",
+			options: []pkg.ClientOption{pkg.WithRateLimit(60, base.RateLimitFailFast)},
+			want:    60,
+		},
+		{
+			name:    "explicit limit on a demo key",
+			apiKey:  "demo-key",
+			options: []pkg.ClientOption{pkg.WithRateLimit(10, base.RateLimitWait)},
+			want:    10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newKeyServer(t, "pro-key")
+			options := append([]pkg.ClientOption{pkg.WithBaseURL(server.URL())}, tt.options...)
+
+			client, err := pkg.NewClientWithDetectedAPIKey(context.Background(), tt.apiKey, options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := client.RateLimiter().RequestsPerMinute(); got != tt.want {
+				t.Errorf("rate limit = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+// Package cassette records HTTP interactions with the CoinGecko API to JSON
+// files and replays them, so that code built on the client can be tested offline.
+//
+// Record once against the real API:
+//
+//	recorder, err := cassette.New("testdata/coins.json", cassette.ModeRecord)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer recorder.Stop()
+//	client := pkg.NewClient(pkg.WithProAPIKey(apiKey), pkg.WithTransport(recorder))
+//
+// Then replay in tests with cassette.ModeReplay. API keys are scrubbed from
+// recorded requests and never written to cassette files.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Version is the version of the cassette file format
+const Version = 1
+
+// ErrNoMatch is returned in strict replay mode for requests matching no recorded interaction
+var ErrNoMatch = errors.New("cassette: no recorded interaction matches request")
+
+// Mode defines whether a Recorder records or replays interactions
+type Mode int
+
+const (
+	// ModeReplay serves recorded interactions
+	ModeReplay Mode = iota
+	// ModeRecord sends requests and records the interactions, saved by Stop
+	ModeRecord
+)
+
+// Cassette is the content of a cassette file
+type Cassette struct {
+	// Version is the version of the file format
+	Version int `json:"version"`
+	// Interactions are the recorded request/response pairs, in the order they were recorded
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request/response pair
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request, without API keys
+type Request struct {
+	Method string     `json:"method"`
+	Path   string     `json:"path"`
+	Query  url.Values `json:"query,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	// Body is the response body when it is valid JSON
+	Body json.RawMessage `json:"body,omitempty"`
+	// RawBody is the response body when it is not valid JSON
+	RawBody string `json:"raw_body,omitempty"`
+}
+
+// Option configures a Recorder
+type Option func(*Recorder)
+
+// WithStrict makes replay fail with ErrNoMatch on unmatched requests
+// instead of sending them with the real transport
+func WithStrict() Option {
+	return func(r *Recorder) {
+		r.strict = true
+	}
+}
+
+// WithRealTransport sets the transport used to send requests that are recorded
+// or not matched, http.DefaultTransport by default
+func WithRealTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithIgnoredQueryParams ignores the given query parameters when matching and recording requests
+func WithIgnoredQueryParams(names ...string) Option {
+	return func(r *Recorder) {
+		for _, name := range names {
+			r.ignored[strings.ToLower(name)] = true
+		}
+	}
+}
+
+// Recorder is an http.RoundTripper recording or replaying interactions of a cassette file
+type Recorder struct {
+	mu        sync.Mutex
+	path      string
+	mode      Mode
+	strict    bool
+	transport http.RoundTripper
+	ignored   map[string]bool
+	cassette  *Cassette
+	used      []bool
+}
+
+// New creates a recorder of the cassette file at path.
+// In replay mode the file must exist; in record mode it is overwritten by Stop.
+func New(path string, mode Mode, options ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		ignored:   make(map[string]bool),
+		cassette:  &Cassette{Version: Version},
+	}
+	for _, option := range options {
+		option(r)
+	}
+
+	if mode == ModeReplay {
+		cassette, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = cassette
+		r.used = make([]bool, len(cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Load reads the cassette file at path
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+	if cassette.Version != Version {
+		return nil, fmt.Errorf("unsupported cassette version %d in %s", cassette.Version, path)
+	}
+
+	return &cassette, nil
+}
+
+// Cassette returns the interactions recorded or loaded so far
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Cassette{
+		Version:      r.cassette.Version,
+		Interactions: append([]*Interaction(nil), r.cassette.Interactions...),
+	}
+}
+
+// Stop saves the recorded interactions to the cassette file in record mode
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded := r.recordedRequest(req)
+
+	if r.mode == ModeReplay {
+		if interaction := r.match(recorded); interaction != nil {
+			return interaction.Response.httpResponse(req)
+		}
+		if r.strict {
+			return nil, fmt.Errorf("%w: %s %s", ErrNoMatch, recorded.Method, recorded.Path+encodeQuery(recorded.Query))
+		}
+		return r.transport.RoundTrip(req)
+	}
+
+	// Let the transport negotiate compression so that bodies are recorded decompressed
+	if req.Header.Get("Accept-Encoding") != "" {
+		req = req.Clone(req.Context())
+		req.Header.Del("Accept-Encoding")
+	}
+
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := &Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     scrubHeader(res.Header),
+		},
+	}
+	if json.Valid(body) {
+		interaction.Response.Body = json.RawMessage(body)
+	} else {
+		interaction.Response.RawBody = string(body)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// match returns the first unused interaction matching req, or the last matching one once all are used
+func (r *Recorder) match(req Request) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var last *Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !interaction.Request.matches(req) {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return interaction
+		}
+		last = interaction
+	}
+	return last
+}
+
+// recordedRequest returns the matched and recorded part of req, without API keys and ignored query parameters
+func (r *Recorder) recordedRequest(req *http.Request) Request {
+	query := url.Values{}
+	for name, values := range req.URL.Query() {
+		if isAPIKeyParam(name) || r.ignored[strings.ToLower(name)] {
+			continue
+		}
+		query[name] = values
+	}
+	if len(query) == 0 {
+		query = nil
+	}
+
+	return Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  query,
+	}
+}
+
+// matches reports whether a recorded request matches req on method, path and query parameters
+func (r Request) matches(req Request) bool {
+	return strings.EqualFold(r.Method, req.Method) &&
+		r.Path == req.Path &&
+		encodeQuery(r.Query) == encodeQuery(req.Query)
+}
+
+// httpResponse builds the response served for req
+func (r Response) httpResponse(req *http.Request) (*http.Response, error) {
+	body := []byte(r.RawBody)
+	if len(r.Body) > 0 {
+		body = r.Body
+	}
+
+	header := r.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	// The recorded body may have been re-encoded, so its original length no longer applies
+	header.Del("Content-Length")
+	header.Del("Content-Encoding")
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// encodeQuery returns query in a canonical form, prefixed with "?" when not empty
+func encodeQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
+
+// scrubHeader returns a copy of header without API keys and cookies
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := http.Header{}
+	for name, values := range header {
+		if isAPIKeyParam(name) || strings.EqualFold(name, "Set-Cookie") {
+			continue
+		}
+		scrubbed[name] = values
+	}
+	return scrubbed
+}
+
+// isAPIKeyParam reports whether a header or query parameter carries an API key,
+// e.g. x-cg-pro-api-key or x_cg_demo_api_key
+func isAPIKeyParam(name string) bool {
+	name = strings.ReplaceAll(strings.ToLower(name), "-", "_")
+	return strings.HasPrefix(name, "x_cg_") && strings.HasSuffix(name, "_api_key")
+}
@@ -0,0 +1,324 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const testAPIKey = "CG-secret-test-key"
+
+// echoServer answers every request with its path and request number,
+// echoing the API key and setting a cookie like a careless upstream would
+type echoServer struct {
+	*httptest.Server
+	calls atomic.Int32
+}
+
+func newEchoServer(t *testing.T) *echoServer {
+	t.Helper()
+
+	s := &echoServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := s.calls.Add(1)
+		w.Header().Set("X-Cg-Pro-Api-Key", r.Header.Get("x-cg-pro-api-key"))
+		w.Header().Set("Set-Cookie", "session="+testAPIKey)
+		if r.URL.Path == "/text" {
+			_, _ = io.WriteString(w, "plain text")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"path":%q,"n":%d}`, r.URL.Path, n)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// offlineTransport fails the test when a request reaches the network
+type offlineTransport struct {
+	t *testing.T
+}
+
+func (o offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	o.t.Errorf("request %s sent to the network", req.URL)
+	return nil, errors.New("offline")
+}
+
+// get sends a GET request for url through transport, with the test API key in a header
+func get(t *testing.T, transport http.RoundTripper, url string) (string, error) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("x-cg-pro-api-key", testAPIKey)
+
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body), nil
+}
+
+// sameBody reports whether two response bodies are equal, ignoring the indentation of JSON bodies
+// which the cassette file re-indents
+func sameBody(a, b string) bool {
+	var compactA, compactB bytes.Buffer
+	if json.Compact(&compactA, []byte(a)) != nil || json.Compact(&compactB, []byte(b)) != nil {
+		return a == b
+	}
+	return compactA.String() == compactB.String()
+}
+
+// record records the responses to urls in a new cassette file and returns its path and the responses
+func record(t *testing.T, urls []string, options ...Option) (string, []string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+	recorder, err := New(path, ModeRecord, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var bodies []string
+	for _, url := range urls {
+		body, err := get(t, recorder, url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bodies = append(bodies, body)
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	return path, bodies
+}
+
+func TestRecordReplayRoundTrip(t *testing.T) {
+	server := newEchoServer(t)
+	urls := []string{
+		server.URL + "/ping",
+		server.URL + "/coins/markets?vs_currency=usd&ids=bitcoin,ethereum",
+		server.URL + "/text",
+	}
+	path, recorded := record(t, urls)
+
+	cassette, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cassette.Interactions) != len(urls) {
+		t.Fatalf("recorded %d interactions, want %d", len(cassette.Interactions), len(urls))
+	}
+	if got := cassette.Interactions[2].Response.RawBody; got != "plain text" {
+		t.Errorf("raw body = %q, want the non-JSON body", got)
+	}
+
+	// Replay serves the recorded responses without the network
+	server.Close()
+	calls := server.calls.Load()
+	recorder, err := New(path, ModeReplay, WithStrict(), WithRealTransport(offlineTransport{t}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, url := range urls {
+		body, err := get(t, recorder, url)
+		if err != nil {
+			t.Fatalf("replay of %s: %v", url, err)
+		}
+		if !sameBody(body, recorded[i]) {
+			t.Errorf("replay of %s = %s, want %s", url, body, recorded[i])
+		}
+	}
+	if got := server.calls.Load(); got != calls {
+		t.Errorf("server received %d requests during replay", got-calls)
+	}
+}
+
+func TestReplayRepeatedRequests(t *testing.T) {
+	server := newEchoServer(t)
+	path, recorded := record(t, []string{server.URL + "/ping", server.URL + "/ping"})
+
+	recorder, err := New(path, ModeReplay, WithRealTransport(offlineTransport{t}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Recorded interactions are served in order, the last one once all are used
+	want := []string{recorded[0], recorded[1], recorded[1]}
+	for i, want := range want {
+		body, err := get(t, recorder, server.URL+"/ping")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sameBody(body, want) {
+			t.Errorf("replay %d = %s, want %s", i, body, want)
+		}
+	}
+}
+
+func TestReplayUnmatchedRequests(t *testing.T) {
+	server := newEchoServer(t)
+	path, _ := record(t, []string{server.URL + "/ping"})
+
+	t.Run("strict", func(t *testing.T) {
+		recorder, err := New(path, ModeReplay, WithStrict(), WithRealTransport(offlineTransport{t}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, url := range []string{server.URL + "/key", server.URL + "/ping?extra=1"} {
+			if _, err := get(t, recorder, url); !errors.Is(err, ErrNoMatch) {
+				t.Errorf("%s: error = %v, want ErrNoMatch", url, err)
+			}
+		}
+	})
+
+	t.Run("real transport", func(t *testing.T) {
+		recorder, err := New(path, ModeReplay, WithRealTransport(http.DefaultTransport))
+		if err != nil {
+			t.Fatal(err)
+		}
+		calls := server.calls.Load()
+		body, err := get(t, recorder, server.URL+"/key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(body, `"/key"`) || server.calls.Load() != calls+1 {
+			t.Errorf("unmatched request not sent to the real transport: %s", body)
+		}
+	})
+}
+
+func TestRecordScrubsAPIKeys(t *testing.T) {
+	server := newEchoServer(t)
+	path, _ := record(t, []string{
+		server.URL + "/ping?x_cg_demo_api_key=" + testAPIKey,
+		server.URL + "/ping?x_cg_pro_api_key=" + testAPIKey + "&vs_currency=usd",
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), testAPIKey) {
+		t.Fatalf("cassette file contains the API key:\n%s", data)
+	}
+
+	cassette, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := cassette.Interactions[0], cassette.Interactions[1]
+	if first.Request.Query != nil {
+		t.Errorf("query = %v, want the API key dropped", first.Request.Query)
+	}
+	if got := second.Request.Query.Encode(); got != "vs_currency=usd" {
+		t.Errorf("query = %s, want vs_currency=usd only", got)
+	}
+	for _, name := range []string{"X-Cg-Pro-Api-Key", "Set-Cookie"} {
+		if _, ok := first.Response.Header[name]; ok {
+			t.Errorf("response header %s recorded", name)
+		}
+	}
+
+	// Requests match their scrubbed recording whatever the key
+	recorder, err := New(path, ModeReplay, WithStrict(), WithRealTransport(offlineTransport{t}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := get(t, recorder, server.URL+"/ping?x_cg_demo_api_key=another-key"); err != nil {
+		t.Errorf("request with another key: %v", err)
+	}
+}
+
+func TestIgnoredQueryParams(t *testing.T) {
+	server := newEchoServer(t)
+	path, recorded := record(t, []string{server.URL + "/simple/price?ids=bitcoin&ts=1"}, WithIgnoredQueryParams("TS"))
+
+	cassette, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cassette.Interactions[0].Request.Query.Encode(); got != "ids=bitcoin" {
+		t.Errorf("recorded query = %s, want ids=bitcoin", got)
+	}
+
+	tests := []struct {
+		name    string
+		options []Option
+		url     string
+		wantErr bool
+	}{
+		{name: "ignored parameter", options: []Option{WithIgnoredQueryParams("ts")}, url: "/simple/price?ids=bitcoin&ts=2"},
+		{name: "ignored parameter missing", options: []Option{WithIgnoredQueryParams("ts")}, url: "/simple/price?ids=bitcoin"},
+		{name: "other parameter", options: []Option{WithIgnoredQueryParams("ts")}, url: "/simple/price?ids=ethereum&ts=2", wantErr: true},
+		{name: "not ignored", url: "/simple/price?ids=bitcoin&ts=2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := append([]Option{WithStrict(), WithRealTransport(offlineTransport{t})}, tt.options...)
+			recorder, err := New(path, ModeReplay, options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body, err := get(t, recorder, server.URL+tt.url)
+			if tt.wantErr {
+				if !errors.Is(err, ErrNoMatch) {
+					t.Errorf("error = %v, want ErrNoMatch", err)
+				}
+				return
+			}
+			if err != nil || !sameBody(body, recorded[0]) {
+				t.Errorf("replay = %s, %v, want %s", body, err, recorded[0])
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	future := filepath.Join(dir, "future.json")
+	if err := os.WriteFile(invalid, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(future, []byte(`{"version":99,"interactions":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{filepath.Join(dir, "missing.json"), invalid, future} {
+		if _, err := New(path, ModeReplay); err == nil {
+			t.Errorf("New(%s) succeeded, want an error", filepath.Base(path))
+		}
+	}
+
+	// Record mode creates the file on Stop
+	recorder, err := New(filepath.Join(dir, "new", "cassette.json"), ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if cassette, err := Load(filepath.Join(dir, "new", "cassette.json")); err != nil || len(cassette.Interactions) != 0 {
+		t.Errorf("Load of an empty recording = %v, %v", cassette, err)
+	}
+}
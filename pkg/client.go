@@ -1,6 +1,9 @@
 package pkg
 
 import (
+	"context"
+	"errors"
+
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/asset_platforms"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/categories"
@@ -13,53 +16,185 @@ import (
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/global"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/key"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/nfts"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/onchain"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/ping"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/search"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/simple"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/trending"
 )
 
-// ClientOption defines client configuration options
-type ClientOption = base.ClientOption
-
 type Client interface {
 	Ping() (*ping.PingResponse, error)
+	PingWithContext(ctx context.Context) (*ping.PingResponse, error)
 	Key() (*key.KeyResponse, error)
+	KeyWithContext(ctx context.Context) (*key.KeyResponse, error)
+	AutoConfigureRateLimit(ctx context.Context) (*key.KeyResponse, error)
+	RateLimiter() *base.RateLimiter
+	APIKeyPool() *base.APIKeyPool
+	RefreshAPIKeyCredits(ctx context.Context) ([]base.APIKeyStats, error)
+	PingAPI() ping.Client
+	KeyAPI() key.Client
+	CoinsAPI() coins.Client
+	SimpleAPI() simple.Client
+	AssetPlatformsAPI() asset_platforms.Client
+	CategoriesAPI() categories.Client
+	ExchangesAPI() exchanges.Client
+	ContractAPI() contract.Client
+	DerivativesAPI() derivatives.Client
+	NFTsAPI() nfts.Client
+	ExchangeRatesAPI() exchange_rates.Client
+	SearchAPI() search.Client
+	TrendingAPI() trending.Client
+	GlobalAPI() global.Client
+	CompaniesAPI() companies.Client
+	OnchainAPI() onchain.Client
 	GetCoinsList(request *coins.GetCoinsListRequest) (*coins.GetCoinsListResponse, error)
+	GetCoinsListWithContext(ctx context.Context, request *coins.GetCoinsListRequest) (*coins.GetCoinsListResponse, error)
+	GetTopGainersAndLosers(request *coins.GetTopGainersAndLosersRequest) (*coins.GetTopGainersAndLosersResponse, error)
+	GetTopGainersAndLosersWithContext(ctx context.Context, request *coins.GetTopGainersAndLosersRequest) (*coins.GetTopGainersAndLosersResponse, error)
+	GetRecentlyAddedCoins() (*coins.GetRecentlyAddedCoinsResponse, error)
+	GetRecentlyAddedCoinsWithContext(ctx context.Context) (*coins.GetRecentlyAddedCoinsResponse, error)
+	GetCoinsListWithMarketData(request *coins.GetCoinsListWithMarketDataRequest) (*coins.GetCoinsListWithMarketDataResponse, error)
+	GetCoinsListWithMarketDataWithContext(ctx context.Context, request *coins.GetCoinsListWithMarketDataRequest) (*coins.GetCoinsListWithMarketDataResponse, error)
+	GetCoinDataByID(request *coins.GetCoinDataByIDRequest) (*coins.GetCoinDataByIDResponse, error)
+	GetCoinDataByIDWithContext(ctx context.Context, request *coins.GetCoinDataByIDRequest) (*coins.GetCoinDataByIDResponse, error)
+	GetCoinTickersByID(request *coins.GetCoinTickersByIDRequest) (*coins.GetCoinTickersByIDResponse, error)
+	GetCoinTickersByIDWithContext(ctx context.Context, request *coins.GetCoinTickersByIDRequest) (*coins.GetCoinTickersByIDResponse, error)
+	GetCoinHistoryByID(request *coins.GetCoinHistoryByIDRequest) (*coins.GetCoinHistoryByIDResponse, error)
+	GetCoinHistoryByIDWithContext(ctx context.Context, request *coins.GetCoinHistoryByIDRequest) (*coins.GetCoinHistoryByIDResponse, error)
+	GetCoinMarketChartByID(request *coins.GetCoinMarketChartByIDRequest) (*coins.GetCoinMarketChartByIDResponse, error)
+	GetCoinMarketChartByIDWithContext(ctx context.Context, request *coins.GetCoinMarketChartByIDRequest) (*coins.GetCoinMarketChartByIDResponse, error)
+	GetCoinMarketChartRange(request *coins.GetCoinMarketChartRangeRequest) (*coins.GetCoinMarketChartRangeResponse, error)
+	GetCoinMarketChartRangeWithContext(ctx context.Context, request *coins.GetCoinMarketChartRangeRequest) (*coins.GetCoinMarketChartRangeResponse, error)
+	GetCoinOHLCByID(request *coins.GetCoinOHLCByIDRequest) (*coins.GetCoinOHLCByIDResponse, error)
+	GetCoinOHLCByIDWithContext(ctx context.Context, request *coins.GetCoinOHLCByIDRequest) (*coins.GetCoinOHLCByIDResponse, error)
+	GetCoinOHLCRange(request *coins.GetCoinOHLCRangeRequest) (*coins.GetCoinOHLCRangeResponse, error)
+	GetCoinOHLCRangeWithContext(ctx context.Context, request *coins.GetCoinOHLCRangeRequest) (*coins.GetCoinOHLCRangeResponse, error)
+	GetCoinCirculatingSupplyChart(request *coins.GetCoinCirculatingSupplyChartRequest) (*coins.GetCoinCirculatingSupplyChartResponse, error)
+	GetCoinCirculatingSupplyChartWithContext(ctx context.Context, request *coins.GetCoinCirculatingSupplyChartRequest) (*coins.GetCoinCirculatingSupplyChartResponse, error)
+	GetCoinCirculatingSupplyChartRange(request *coins.GetCoinCirculatingSupplyChartRangeRequest) (*coins.GetCoinCirculatingSupplyChartRangeResponse, error)
+	GetCoinCirculatingSupplyChartRangeWithContext(ctx context.Context, request *coins.GetCoinCirculatingSupplyChartRangeRequest) (*coins.GetCoinCirculatingSupplyChartRangeResponse, error)
+	GetCoinTotalSupplyChart(request *coins.GetCoinTotalSupplyChartRequest) (*coins.GetCoinTotalSupplyChartResponse, error)
+	GetCoinTotalSupplyChartWithContext(ctx context.Context, request *coins.GetCoinTotalSupplyChartRequest) (*coins.GetCoinTotalSupplyChartResponse, error)
+	GetCoinTotalSupplyChartRange(request *coins.GetCoinTotalSupplyChartRangeRequest) (*coins.GetCoinTotalSupplyChartRangeResponse, error)
+	GetCoinTotalSupplyChartRangeWithContext(ctx context.Context, request *coins.GetCoinTotalSupplyChartRangeRequest) (*coins.GetCoinTotalSupplyChartRangeResponse, error)
 	GetCoinPriceByIDs(request *simple.GetCoinPriceByIDsRequest) (*simple.GetCoinPriceByIDsResponse, error)
+	GetCoinPriceByIDsWithContext(ctx context.Context, request *simple.GetCoinPriceByIDsRequest) (*simple.GetCoinPriceByIDsResponse, error)
 	GetCoinPriceByTokenAddress(request *simple.GetCoinPriceByTokenAddressRequest) (*simple.GetCoinPriceByTokenAddressResponse, error)
+	GetCoinPriceByTokenAddressWithContext(ctx context.Context, request *simple.GetCoinPriceByTokenAddressRequest) (*simple.GetCoinPriceByTokenAddressResponse, error)
 	GetSupportedCurrencies() (*simple.GetSupportedCurrenciesResponse, error)
+	GetSupportedCurrenciesWithContext(ctx context.Context) (*simple.GetSupportedCurrenciesResponse, error)
 	GetAssetPlatforms() (*asset_platforms.GetAssetPlatformsResponse, error)
+	GetAssetPlatformsWithContext(ctx context.Context) (*asset_platforms.GetAssetPlatformsResponse, error)
+	GetTokenListsByAssetPlatformID(request *asset_platforms.GetTokenListsByAssetPlatformIDRequest) (*asset_platforms.GetTokenListsByAssetPlatformIDResponse, error)
+	GetTokenListsByAssetPlatformIDWithContext(ctx context.Context, request *asset_platforms.GetTokenListsByAssetPlatformIDRequest) (*asset_platforms.GetTokenListsByAssetPlatformIDResponse, error)
 	GetCategoriesList() (*categories.GetCategoriesListResponse, error)
+	GetCategoriesListWithContext(ctx context.Context) (*categories.GetCategoriesListResponse, error)
 	GetCategoriesData(request *categories.GetCategoriesDataRequest) (*categories.GetCategoriesDataResponse, error)
+	GetCategoriesDataWithContext(ctx context.Context, request *categories.GetCategoriesDataRequest) (*categories.GetCategoriesDataResponse, error)
 	GetExchangesList(request *exchanges.GetExchangesListRequest) (*exchanges.GetExchangesListResponse, error)
+	GetExchangesListWithContext(ctx context.Context, request *exchanges.GetExchangesListRequest) (*exchanges.GetExchangesListResponse, error)
+	GetExchangesListID() (*exchanges.GetExchangesListIDResponse, error)
+	GetExchangesListIDWithContext(ctx context.Context) (*exchanges.GetExchangesListIDResponse, error)
 	GetExchangeData(request *exchanges.GetExchangeDataRequest) (*exchanges.GetExchangeDataResponse, error)
+	GetExchangeDataWithContext(ctx context.Context, request *exchanges.GetExchangeDataRequest) (*exchanges.GetExchangeDataResponse, error)
 	GetExchangeTickers(request *exchanges.GetExchangeTickersRequest) (*exchanges.GetExchangeTickersResponse, error)
+	GetExchangeTickersWithContext(ctx context.Context, request *exchanges.GetExchangeTickersRequest) (*exchanges.GetExchangeTickersResponse, error)
 	GetExchangeVolumeChart(request *exchanges.GetExchangeVolumeChartRequest) (*exchanges.GetExchangeVolumeChartResponse, error)
+	GetExchangeVolumeChartWithContext(ctx context.Context, request *exchanges.GetExchangeVolumeChartRequest) (*exchanges.GetExchangeVolumeChartResponse, error)
 	GetContractData(request *contract.GetContractDataRequest) (*contract.GetContractDataResponse, error)
+	GetContractDataWithContext(ctx context.Context, request *contract.GetContractDataRequest) (*contract.GetContractDataResponse, error)
 	GetContractMarketChart(request *contract.GetContractMarketChartRequest) (*contract.GetContractMarketChartResponse, error)
+	GetContractMarketChartWithContext(ctx context.Context, request *contract.GetContractMarketChartRequest) (*contract.GetContractMarketChartResponse, error)
 	GetContractMarketChartRange(request *contract.GetContractMarketChartRangeRequest) (*contract.GetContractMarketChartRangeResponse, error)
+	GetContractMarketChartRangeWithContext(ctx context.Context, request *contract.GetContractMarketChartRangeRequest) (*contract.GetContractMarketChartRangeResponse, error)
 	GetDerivativesList() (*derivatives.GetDerivativesListResponse, error)
+	GetDerivativesListWithContext(ctx context.Context) (*derivatives.GetDerivativesListResponse, error)
 	GetDerivativesExchangesList() (*derivatives.GetDerivativesExchangesListResponse, error)
+	GetDerivativesExchangesListWithContext(ctx context.Context) (*derivatives.GetDerivativesExchangesListResponse, error)
 	GetDerivativeExchangeData(request *derivatives.GetDerivativeExchangeDataRequest) (*derivatives.GetDerivativeExchangeDataResponse, error)
+	GetDerivativeExchangeDataWithContext(ctx context.Context, request *derivatives.GetDerivativeExchangeDataRequest) (*derivatives.GetDerivativeExchangeDataResponse, error)
 	GetDerivativesExchangesListIDMap() (*derivatives.GetDerivativesExchangesListIDMapResponse, error)
+	GetDerivativesExchangesListIDMapWithContext(ctx context.Context) (*derivatives.GetDerivativesExchangesListIDMapResponse, error)
 	GetNFTsList() (*nfts.GetNFTsListResponse, error)
+	GetNFTsListWithContext(ctx context.Context) (*nfts.GetNFTsListResponse, error)
 	GetNFTData(request *nfts.GetNFTDataRequest) (*nfts.GetNFTDataResponse, error)
+	GetNFTDataWithContext(ctx context.Context, request *nfts.GetNFTDataRequest) (*nfts.GetNFTDataResponse, error)
 	GetNFTContractData(request *nfts.GetNFTContractDataRequest) (*nfts.GetNFTContractDataResponse, error)
+	GetNFTContractDataWithContext(ctx context.Context, request *nfts.GetNFTContractDataRequest) (*nfts.GetNFTContractDataResponse, error)
 	GetNFTsMarketData(request *nfts.GetNFTsMarketDataRequest) (*nfts.GetNFTsMarketDataResponse, error)
+	GetNFTsMarketDataWithContext(ctx context.Context, request *nfts.GetNFTsMarketDataRequest) (*nfts.GetNFTsMarketDataResponse, error)
 	GetNFTHistory(request *nfts.GetNFTHistoryRequest) (*nfts.GetNFTHistoryResponse, error)
+	GetNFTHistoryWithContext(ctx context.Context, request *nfts.GetNFTHistoryRequest) (*nfts.GetNFTHistoryResponse, error)
 	GetNFTContractHistory(request *nfts.GetNFTContractHistoryRequest) (*nfts.GetNFTContractHistoryResponse, error)
+	GetNFTContractHistoryWithContext(ctx context.Context, request *nfts.GetNFTContractHistoryRequest) (*nfts.GetNFTContractHistoryResponse, error)
 	GetNFTTickers(request *nfts.GetNFTTickersRequest) (*nfts.GetNFTTickersResponse, error)
+	GetNFTTickersWithContext(ctx context.Context, request *nfts.GetNFTTickersRequest) (*nfts.GetNFTTickersResponse, error)
 	GetExchangeRates() (*exchange_rates.GetExchangeRatesResponse, error)
+	GetExchangeRatesWithContext(ctx context.Context) (*exchange_rates.GetExchangeRatesResponse, error)
+	GetExchangeRate(request *exchange_rates.GetExchangeRateRequest) (*exchange_rates.GetExchangeRateResponse, error)
+	GetExchangeRateWithContext(ctx context.Context, request *exchange_rates.GetExchangeRateRequest) (*exchange_rates.GetExchangeRateResponse, error)
 	Search(request *search.SearchRequest) (*search.SearchResponse, error)
+	SearchWithContext(ctx context.Context, request *search.SearchRequest) (*search.SearchResponse, error)
 	GetTrending() (*trending.TrendingResponse, error)
+	GetTrendingWithContext(ctx context.Context) (*trending.TrendingResponse, error)
 	GetGlobal() (*global.GetGlobalResponse, error)
+	GetGlobalWithContext(ctx context.Context) (*global.GetGlobalResponse, error)
 	GetGlobalDefi() (*global.GetGlobalDefiResponse, error)
+	GetGlobalDefiWithContext(ctx context.Context) (*global.GetGlobalDefiResponse, error)
 	GetGlobalMarketCapChart(vsCurrency string, days string) (*global.GetGlobalMarketCapChartResponse, error)
+	GetGlobalMarketCapChartWithContext(ctx context.Context, vsCurrency string, days string) (*global.GetGlobalMarketCapChartResponse, error)
 	GetPublicTreasury() (*companies.GetPublicTreasuryResponse, error)
+	GetPublicTreasuryWithContext(ctx context.Context) (*companies.GetPublicTreasuryResponse, error)
+	GetNetworks(request *onchain.GetNetworksRequest) (*onchain.GetNetworksResponse, error)
+	GetNetworksWithContext(ctx context.Context, request *onchain.GetNetworksRequest) (*onchain.GetNetworksResponse, error)
+	GetNetworkDexes(request *onchain.GetNetworkDexesRequest) (*onchain.GetNetworkDexesResponse, error)
+	GetNetworkDexesWithContext(ctx context.Context, request *onchain.GetNetworkDexesRequest) (*onchain.GetNetworkDexesResponse, error)
+	GetPool(request *onchain.GetPoolRequest) (*onchain.GetPoolResponse, error)
+	GetPoolWithContext(ctx context.Context, request *onchain.GetPoolRequest) (*onchain.GetPoolResponse, error)
+	GetMultiplePools(request *onchain.GetMultiplePoolsRequest) (*onchain.GetMultiplePoolsResponse, error)
+	GetMultiplePoolsWithContext(ctx context.Context, request *onchain.GetMultiplePoolsRequest) (*onchain.GetMultiplePoolsResponse, error)
+	SearchPools(request *onchain.SearchPoolsRequest) (*onchain.SearchPoolsResponse, error)
+	SearchPoolsWithContext(ctx context.Context, request *onchain.SearchPoolsRequest) (*onchain.SearchPoolsResponse, error)
+	GetTrendingPools(request *onchain.GetTrendingPoolsRequest) (*onchain.GetTrendingPoolsResponse, error)
+	GetTrendingPoolsWithContext(ctx context.Context, request *onchain.GetTrendingPoolsRequest) (*onchain.GetTrendingPoolsResponse, error)
+	GetNetworkTrendingPools(request *onchain.GetNetworkTrendingPoolsRequest) (*onchain.GetNetworkTrendingPoolsResponse, error)
+	GetNetworkTrendingPoolsWithContext(ctx context.Context, request *onchain.GetNetworkTrendingPoolsRequest) (*onchain.GetNetworkTrendingPoolsResponse, error)
+	GetTopPools(request *onchain.GetTopPoolsRequest) (*onchain.GetTopPoolsResponse, error)
+	GetTopPoolsWithContext(ctx context.Context, request *onchain.GetTopPoolsRequest) (*onchain.GetTopPoolsResponse, error)
+	GetDexTopPools(request *onchain.GetDexTopPoolsRequest) (*onchain.GetDexTopPoolsResponse, error)
+	GetDexTopPoolsWithContext(ctx context.Context, request *onchain.GetDexTopPoolsRequest) (*onchain.GetDexTopPoolsResponse, error)
+	GetNewPools(request *onchain.GetNewPoolsRequest) (*onchain.GetNewPoolsResponse, error)
+	GetNewPoolsWithContext(ctx context.Context, request *onchain.GetNewPoolsRequest) (*onchain.GetNewPoolsResponse, error)
+	GetNetworkNewPools(request *onchain.GetNetworkNewPoolsRequest) (*onchain.GetNetworkNewPoolsResponse, error)
+	GetNetworkNewPoolsWithContext(ctx context.Context, request *onchain.GetNetworkNewPoolsRequest) (*onchain.GetNetworkNewPoolsResponse, error)
+	TrendingPoolsPages(request *onchain.GetTrendingPoolsRequest) *onchain.PoolPager
+	NetworkTrendingPoolsPages(request *onchain.GetNetworkTrendingPoolsRequest) *onchain.PoolPager
+	TopPoolsPages(request *onchain.GetTopPoolsRequest) *onchain.PoolPager
+	DexTopPoolsPages(request *onchain.GetDexTopPoolsRequest) *onchain.PoolPager
+	NewPoolsPages(request *onchain.GetNewPoolsRequest) *onchain.PoolPager
+	NetworkNewPoolsPages(request *onchain.GetNetworkNewPoolsRequest) *onchain.PoolPager
+	GetPoolOHLCV(request *onchain.GetPoolOHLCVRequest) (*onchain.GetPoolOHLCVResponse, error)
+	GetPoolOHLCVWithContext(ctx context.Context, request *onchain.GetPoolOHLCVRequest) (*onchain.GetPoolOHLCVResponse, error)
+	PoolOHLCVPages(request *onchain.GetPoolOHLCVRequest) *onchain.OHLCVPager
+	GetPoolTrades(request *onchain.GetPoolTradesRequest) (*onchain.GetPoolTradesResponse, error)
+	GetPoolTradesWithContext(ctx context.Context, request *onchain.GetPoolTradesRequest) (*onchain.GetPoolTradesResponse, error)
+	GetToken(request *onchain.GetTokenRequest) (*onchain.GetTokenResponse, error)
+	GetTokenWithContext(ctx context.Context, request *onchain.GetTokenRequest) (*onchain.GetTokenResponse, error)
+	GetMultipleTokens(request *onchain.GetMultipleTokensRequest) (*onchain.GetMultipleTokensResponse, error)
+	GetMultipleTokensWithContext(ctx context.Context, request *onchain.GetMultipleTokensRequest) (*onchain.GetMultipleTokensResponse, error)
+	GetTokenInfo(request *onchain.GetTokenInfoRequest) (*onchain.GetTokenInfoResponse, error)
+	GetTokenInfoWithContext(ctx context.Context, request *onchain.GetTokenInfoRequest) (*onchain.GetTokenInfoResponse, error)
+	GetTokenPools(request *onchain.GetTokenPoolsRequest) (*onchain.GetTokenPoolsResponse, error)
+	GetTokenPoolsWithContext(ctx context.Context, request *onchain.GetTokenPoolsRequest) (*onchain.GetTokenPoolsResponse, error)
+	TokenPoolsPages(request *onchain.GetTokenPoolsRequest) *onchain.PoolPager
+	GetTokenPrice(request *onchain.GetTokenPriceRequest) (*onchain.GetTokenPriceResponse, error)
+	GetTokenPriceWithContext(ctx context.Context, request *onchain.GetTokenPriceRequest) (*onchain.GetTokenPriceResponse, error)
 }
 
 type ClientImpl struct {
+	baseClient *base.BaseClient
+
 	PingClient           ping.Client
 	KeyClient            key.Client
 	CoinsClient          coins.Client
@@ -75,13 +210,24 @@ type ClientImpl struct {
 	TrendingClient       trending.Client
 	GlobalClient         global.Client
 	CompaniesClient      companies.Client
+	OnchainClient        onchain.Client
 }
 
+// NewClient creates a client from the default configuration adjusted by options
 func NewClient(options ...ClientOption) Client {
-	config := base.DefaultConfig()
+	return newClientImpl(base.DefaultConfig(), options...)
+}
+
+// NewClientFromConfig creates a client from config adjusted by options
+func NewClientFromConfig(config *base.Config, options ...ClientOption) Client {
+	return newClientImpl(config, options...)
+}
+
+// newClientImpl creates the client implementation with every endpoint client sharing one base client
+func newClientImpl(config *base.Config, options ...ClientOption) *ClientImpl {
 	baseClient := base.NewBaseClient(config, options...)
 
-	client := &ClientImpl{}
+	client := &ClientImpl{baseClient: baseClient}
 	client.PingClient = ping.NewClient(baseClient)
 	client.KeyClient = key.NewClient(baseClient)
 	client.CoinsClient = coins.NewClient(baseClient)
@@ -97,6 +243,7 @@ func NewClient(options ...ClientOption) Client {
 	client.TrendingClient = trending.NewClient(baseClient)
 	client.GlobalClient = global.NewClient(baseClient)
 	client.CompaniesClient = companies.NewClient(baseClient)
+	client.OnchainClient = onchain.NewClient(baseClient)
 
 	return client
 }
@@ -105,134 +252,717 @@ func (c ClientImpl) Ping() (*ping.PingResponse, error) {
 	return c.PingClient.Ping()
 }
 
+func (c ClientImpl) PingWithContext(ctx context.Context) (*ping.PingResponse, error) {
+	return c.PingClient.PingWithContext(ctx)
+}
+
 func (c ClientImpl) Key() (*key.KeyResponse, error) {
 	return c.KeyClient.Key()
 }
 
+func (c ClientImpl) KeyWithContext(ctx context.Context) (*key.KeyResponse, error) {
+	return c.KeyClient.KeyWithContext(ctx)
+}
+
+// AutoConfigureRateLimit queries the /key endpoint and sets the client-side
+// rate limit to the rate allowed by the API key's plan
+func (c ClientImpl) AutoConfigureRateLimit(ctx context.Context) (*key.KeyResponse, error) {
+	response, err := c.KeyClient.KeyWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requestsPerMinute := response.RateLimitRequestPerMinute
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = base.PlanRateLimit(response.Plan)
+	}
+	if requestsPerMinute > 0 {
+		c.baseClient.SetRateLimit(requestsPerMinute)
+	}
+
+	return response, nil
+}
+
+// RateLimiter returns the client-side rate limiter shared by every endpoint client
+func (c ClientImpl) RateLimiter() *base.RateLimiter {
+	return c.baseClient.RateLimiter()
+}
+
+// APIKeyPool returns the configured API key pool, nil when the client uses a single key
+func (c ClientImpl) APIKeyPool() *base.APIKeyPool {
+	return c.baseClient.APIKeyPool()
+}
+
+// RefreshAPIKeyCredits queries the /key endpoint with every key of the API key pool,
+// records their remaining monthly credits and returns the usage of every key.
+// Keys rejected as invalid are revoked.
+func (c ClientImpl) RefreshAPIKeyCredits(ctx context.Context) ([]base.APIKeyStats, error) {
+	pool := c.baseClient.APIKeyPool()
+	if pool == nil {
+		return nil, errors.New("no API key pool configured")
+	}
+
+	var errs []error
+	for _, apiKey := range pool.Keys() {
+		response, err := c.KeyClient.KeyWithContext(base.WithRequestAPIKey(ctx, apiKey))
+		if err != nil {
+			if base.IsUnauthorized(err) {
+				pool.Revoke(apiKey)
+				continue
+			}
+			errs = append(errs, err)
+			continue
+		}
+		pool.UpdateCredits(apiKey, response.MonthlyCallCredit, response.CurrentRemainingMonthlyCalls)
+	}
+
+	return pool.Stats(), errors.Join(errs...)
+}
+
+// PingAPI returns the client of the /ping endpoints
+func (c ClientImpl) PingAPI() ping.Client {
+	return c.PingClient
+}
+
+// KeyAPI returns the client of the /key endpoints
+func (c ClientImpl) KeyAPI() key.Client {
+	return c.KeyClient
+}
+
+// CoinsAPI returns the client of the /coins endpoints
+func (c ClientImpl) CoinsAPI() coins.Client {
+	return c.CoinsClient
+}
+
+// SimpleAPI returns the client of the /simple endpoints
+func (c ClientImpl) SimpleAPI() simple.Client {
+	return c.SampleClient
+}
+
+// AssetPlatformsAPI returns the client of the /asset_platforms endpoints
+func (c ClientImpl) AssetPlatformsAPI() asset_platforms.Client {
+	return c.AssetPlatformsClient
+}
+
+// CategoriesAPI returns the client of the /coins/categories endpoints
+func (c ClientImpl) CategoriesAPI() categories.Client {
+	return c.CategoriesClient
+}
+
+// ExchangesAPI returns the client of the /exchanges endpoints
+func (c ClientImpl) ExchangesAPI() exchanges.Client {
+	return c.ExchangesClient
+}
+
+// ContractAPI returns the client of the /coins/{asset_platform_id}/contract endpoints
+func (c ClientImpl) ContractAPI() contract.Client {
+	return c.ContractClient
+}
+
+// DerivativesAPI returns the client of the /derivatives endpoints
+func (c ClientImpl) DerivativesAPI() derivatives.Client {
+	return c.DerivativesClient
+}
+
+// NFTsAPI returns the client of the /nfts endpoints
+func (c ClientImpl) NFTsAPI() nfts.Client {
+	return c.NFTsClient
+}
+
+// ExchangeRatesAPI returns the client of the /exchange_rates endpoints
+func (c ClientImpl) ExchangeRatesAPI() exchange_rates.Client {
+	return c.ExchangeRatesClient
+}
+
+// SearchAPI returns the client of the /search endpoints
+func (c ClientImpl) SearchAPI() search.Client {
+	return c.SearchClient
+}
+
+// TrendingAPI returns the client of the /search/trending endpoints
+func (c ClientImpl) TrendingAPI() trending.Client {
+	return c.TrendingClient
+}
+
+// GlobalAPI returns the client of the /global endpoints
+func (c ClientImpl) GlobalAPI() global.Client {
+	return c.GlobalClient
+}
+
+// CompaniesAPI returns the client of the /companies endpoints
+func (c ClientImpl) CompaniesAPI() companies.Client {
+	return c.CompaniesClient
+}
+
+// OnchainAPI returns the client of the /onchain endpoints, the DEX data of GeckoTerminal
+func (c ClientImpl) OnchainAPI() onchain.Client {
+	return c.OnchainClient
+}
+
 func (c ClientImpl) GetCoinsList(request *coins.GetCoinsListRequest) (*coins.GetCoinsListResponse, error) {
 	return c.CoinsClient.GetCoinsList(request)
 }
 
+func (c ClientImpl) GetCoinsListWithContext(ctx context.Context, request *coins.GetCoinsListRequest) (*coins.GetCoinsListResponse, error) {
+	return c.CoinsClient.GetCoinsListWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetTopGainersAndLosers(request *coins.GetTopGainersAndLosersRequest) (*coins.GetTopGainersAndLosersResponse, error) {
+	return c.CoinsClient.GetTopGainersAndLosers(request)
+}
+
+func (c ClientImpl) GetTopGainersAndLosersWithContext(ctx context.Context, request *coins.GetTopGainersAndLosersRequest) (*coins.GetTopGainersAndLosersResponse, error) {
+	return c.CoinsClient.GetTopGainersAndLosersWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetRecentlyAddedCoins() (*coins.GetRecentlyAddedCoinsResponse, error) {
+	return c.CoinsClient.GetRecentlyAddedCoins()
+}
+
+func (c ClientImpl) GetRecentlyAddedCoinsWithContext(ctx context.Context) (*coins.GetRecentlyAddedCoinsResponse, error) {
+	return c.CoinsClient.GetRecentlyAddedCoinsWithContext(ctx)
+}
+
+func (c ClientImpl) GetCoinsListWithMarketData(request *coins.GetCoinsListWithMarketDataRequest) (*coins.GetCoinsListWithMarketDataResponse, error) {
+	return c.CoinsClient.GetCoinsListWithMarketData(request)
+}
+
+func (c ClientImpl) GetCoinsListWithMarketDataWithContext(ctx context.Context, request *coins.GetCoinsListWithMarketDataRequest) (*coins.GetCoinsListWithMarketDataResponse, error) {
+	return c.CoinsClient.GetCoinsListWithMarketDataWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetCoinDataByID(request *coins.GetCoinDataByIDRequest) (*coins.GetCoinDataByIDResponse, error) {
+	return c.CoinsClient.GetCoinDataByID(request)
+}
+
+func (c ClientImpl) GetCoinDataByIDWithContext(ctx context.Context, request *coins.GetCoinDataByIDRequest) (*coins.GetCoinDataByIDResponse, error) {
+	return c.CoinsClient.GetCoinDataByIDWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetCoinTickersByID(request *coins.GetCoinTickersByIDRequest) (*coins.GetCoinTickersByIDResponse, error) {
+	return c.CoinsClient.GetCoinTickersByID(request)
+}
+
+func (c ClientImpl) GetCoinTickersByIDWithContext(ctx context.Context, request *coins.GetCoinTickersByIDRequest) (*coins.GetCoinTickersByIDResponse, error) {
+	return c.CoinsClient.GetCoinTickersByIDWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetCoinHistoryByID(request *coins.GetCoinHistoryByIDRequest) (*coins.GetCoinHistoryByIDResponse, error) {
+	return c.CoinsClient.GetCoinHistoryByID(request)
+}
+
+func (c ClientImpl) GetCoinHistoryByIDWithContext(ctx context.Context, request *coins.GetCoinHistoryByIDRequest) (*coins.GetCoinHistoryByIDResponse, error) {
+	return c.CoinsClient.GetCoinHistoryByIDWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetCoinMarketChartByID(request *coins.GetCoinMarketChartByIDRequest) (*coins.GetCoinMarketChartByIDResponse, error) {
+	return c.CoinsClient.GetCoinMarketChartByID(request)
+}
+
+func (c ClientImpl) GetCoinMarketChartByIDWithContext(ctx context.Context, request *coins.GetCoinMarketChartByIDRequest) (*coins.GetCoinMarketChartByIDResponse, error) {
+	return c.CoinsClient.GetCoinMarketChartByIDWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetCoinMarketChartRange(request *coins.GetCoinMarketChartRangeRequest) (*coins.GetCoinMarketChartRangeResponse, error) {
+	return c.CoinsClient.GetCoinMarketChartRange(request)
+}
+
+func (c ClientImpl) GetCoinMarketChartRangeWithContext(ctx context.Context, request *coins.GetCoinMarketChartRangeRequest) (*coins.GetCoinMarketChartRangeResponse, error) {
+	return c.CoinsClient.GetCoinMarketChartRangeWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetCoinOHLCByID(request *coins.GetCoinOHLCByIDRequest) (*coins.GetCoinOHLCByIDResponse, error) {
+	return c.CoinsClient.GetCoinOHLCByID(request)
+}
+
+func (c ClientImpl) GetCoinOHLCByIDWithContext(ctx context.Context, request *coins.GetCoinOHLCByIDRequest) (*coins.GetCoinOHLCByIDResponse, error) {
+	return c.CoinsClient.GetCoinOHLCByIDWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetCoinOHLCRange(request *coins.GetCoinOHLCRangeRequest) (*coins.GetCoinOHLCRangeResponse, error) {
+	return c.CoinsClient.GetCoinOHLCRange(request)
+}
+
+func (c ClientImpl) GetCoinOHLCRangeWithContext(ctx context.Context, request *coins.GetCoinOHLCRangeRequest) (*coins.GetCoinOHLCRangeResponse, error) {
+	return c.CoinsClient.GetCoinOHLCRangeWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetCoinCirculatingSupplyChart(request *coins.GetCoinCirculatingSupplyChartRequest) (*coins.GetCoinCirculatingSupplyChartResponse, error) {
+	return c.CoinsClient.GetCoinCirculatingSupplyChart(request)
+}
+
+func (c ClientImpl) GetCoinCirculatingSupplyChartWithContext(ctx context.Context, request *coins.GetCoinCirculatingSupplyChartRequest) (*coins.GetCoinCirculatingSupplyChartResponse, error) {
+	return c.CoinsClient.GetCoinCirculatingSupplyChartWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetCoinCirculatingSupplyChartRange(request *coins.GetCoinCirculatingSupplyChartRangeRequest) (*coins.GetCoinCirculatingSupplyChartRangeResponse, error) {
+	return c.CoinsClient.GetCoinCirculatingSupplyChartRange(request)
+}
+
+func (c ClientImpl) GetCoinCirculatingSupplyChartRangeWithContext(ctx context.Context, request *coins.GetCoinCirculatingSupplyChartRangeRequest) (*coins.GetCoinCirculatingSupplyChartRangeResponse, error) {
+	return c.CoinsClient.GetCoinCirculatingSupplyChartRangeWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetCoinTotalSupplyChart(request *coins.GetCoinTotalSupplyChartRequest) (*coins.GetCoinTotalSupplyChartResponse, error) {
+	return c.CoinsClient.GetCoinTotalSupplyChart(request)
+}
+
+func (c ClientImpl) GetCoinTotalSupplyChartWithContext(ctx context.Context, request *coins.GetCoinTotalSupplyChartRequest) (*coins.GetCoinTotalSupplyChartResponse, error) {
+	return c.CoinsClient.GetCoinTotalSupplyChartWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetCoinTotalSupplyChartRange(request *coins.GetCoinTotalSupplyChartRangeRequest) (*coins.GetCoinTotalSupplyChartRangeResponse, error) {
+	return c.CoinsClient.GetCoinTotalSupplyChartRange(request)
+}
+
+func (c ClientImpl) GetCoinTotalSupplyChartRangeWithContext(ctx context.Context, request *coins.GetCoinTotalSupplyChartRangeRequest) (*coins.GetCoinTotalSupplyChartRangeResponse, error) {
+	return c.CoinsClient.GetCoinTotalSupplyChartRangeWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetCoinPriceByIDs(request *simple.GetCoinPriceByIDsRequest) (*simple.GetCoinPriceByIDsResponse, error) {
 	return c.SampleClient.GetCoinPriceByIDs(request)
 }
 
+func (c ClientImpl) GetCoinPriceByIDsWithContext(ctx context.Context, request *simple.GetCoinPriceByIDsRequest) (*simple.GetCoinPriceByIDsResponse, error) {
+	return c.SampleClient.GetCoinPriceByIDsWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetCoinPriceByTokenAddress(request *simple.GetCoinPriceByTokenAddressRequest) (*simple.GetCoinPriceByTokenAddressResponse, error) {
 	return c.SampleClient.GetCoinPriceByTokenAddress(request)
 }
 
+func (c ClientImpl) GetCoinPriceByTokenAddressWithContext(ctx context.Context, request *simple.GetCoinPriceByTokenAddressRequest) (*simple.GetCoinPriceByTokenAddressResponse, error) {
+	return c.SampleClient.GetCoinPriceByTokenAddressWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetSupportedCurrencies() (*simple.GetSupportedCurrenciesResponse, error) {
 	return c.SampleClient.GetSupportedCurrencies()
 }
 
+func (c ClientImpl) GetSupportedCurrenciesWithContext(ctx context.Context) (*simple.GetSupportedCurrenciesResponse, error) {
+	return c.SampleClient.GetSupportedCurrenciesWithContext(ctx)
+}
+
 func (c ClientImpl) GetAssetPlatforms() (*asset_platforms.GetAssetPlatformsResponse, error) {
 	return c.AssetPlatformsClient.GetAssetPlatforms()
 }
 
+func (c ClientImpl) GetAssetPlatformsWithContext(ctx context.Context) (*asset_platforms.GetAssetPlatformsResponse, error) {
+	return c.AssetPlatformsClient.GetAssetPlatformsWithContext(ctx)
+}
+
+func (c ClientImpl) GetTokenListsByAssetPlatformID(request *asset_platforms.GetTokenListsByAssetPlatformIDRequest) (*asset_platforms.GetTokenListsByAssetPlatformIDResponse, error) {
+	return c.AssetPlatformsClient.GetTokenListsByAssetPlatformID(request)
+}
+
+func (c ClientImpl) GetTokenListsByAssetPlatformIDWithContext(ctx context.Context, request *asset_platforms.GetTokenListsByAssetPlatformIDRequest) (*asset_platforms.GetTokenListsByAssetPlatformIDResponse, error) {
+	return c.AssetPlatformsClient.GetTokenListsByAssetPlatformIDWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetCategoriesList() (*categories.GetCategoriesListResponse, error) {
 	return c.CategoriesClient.GetCategoriesList()
 }
 
+func (c ClientImpl) GetCategoriesListWithContext(ctx context.Context) (*categories.GetCategoriesListResponse, error) {
+	return c.CategoriesClient.GetCategoriesListWithContext(ctx)
+}
+
 func (c ClientImpl) GetCategoriesData(request *categories.GetCategoriesDataRequest) (*categories.GetCategoriesDataResponse, error) {
 	return c.CategoriesClient.GetCategoriesData(request)
 }
 
+func (c ClientImpl) GetCategoriesDataWithContext(ctx context.Context, request *categories.GetCategoriesDataRequest) (*categories.GetCategoriesDataResponse, error) {
+	return c.CategoriesClient.GetCategoriesDataWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetExchangesList(request *exchanges.GetExchangesListRequest) (*exchanges.GetExchangesListResponse, error) {
 	return c.ExchangesClient.GetExchangesList(request)
 }
 
+func (c ClientImpl) GetExchangesListWithContext(ctx context.Context, request *exchanges.GetExchangesListRequest) (*exchanges.GetExchangesListResponse, error) {
+	return c.ExchangesClient.GetExchangesListWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetExchangesListID() (*exchanges.GetExchangesListIDResponse, error) {
+	return c.ExchangesClient.GetExchangesListID()
+}
+
+func (c ClientImpl) GetExchangesListIDWithContext(ctx context.Context) (*exchanges.GetExchangesListIDResponse, error) {
+	return c.ExchangesClient.GetExchangesListIDWithContext(ctx)
+}
+
 func (c ClientImpl) GetExchangeData(request *exchanges.GetExchangeDataRequest) (*exchanges.GetExchangeDataResponse, error) {
 	return c.ExchangesClient.GetExchangeData(request)
 }
 
+func (c ClientImpl) GetExchangeDataWithContext(ctx context.Context, request *exchanges.GetExchangeDataRequest) (*exchanges.GetExchangeDataResponse, error) {
+	return c.ExchangesClient.GetExchangeDataWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetExchangeTickers(request *exchanges.GetExchangeTickersRequest) (*exchanges.GetExchangeTickersResponse, error) {
 	return c.ExchangesClient.GetExchangeTickers(request)
 }
 
+func (c ClientImpl) GetExchangeTickersWithContext(ctx context.Context, request *exchanges.GetExchangeTickersRequest) (*exchanges.GetExchangeTickersResponse, error) {
+	return c.ExchangesClient.GetExchangeTickersWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetExchangeVolumeChart(request *exchanges.GetExchangeVolumeChartRequest) (*exchanges.GetExchangeVolumeChartResponse, error) {
 	return c.ExchangesClient.GetExchangeVolumeChart(request)
 }
 
+func (c ClientImpl) GetExchangeVolumeChartWithContext(ctx context.Context, request *exchanges.GetExchangeVolumeChartRequest) (*exchanges.GetExchangeVolumeChartResponse, error) {
+	return c.ExchangesClient.GetExchangeVolumeChartWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetContractData(request *contract.GetContractDataRequest) (*contract.GetContractDataResponse, error) {
 	return c.ContractClient.GetContractData(request)
 }
 
+func (c ClientImpl) GetContractDataWithContext(ctx context.Context, request *contract.GetContractDataRequest) (*contract.GetContractDataResponse, error) {
+	return c.ContractClient.GetContractDataWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetContractMarketChart(request *contract.GetContractMarketChartRequest) (*contract.GetContractMarketChartResponse, error) {
 	return c.ContractClient.GetContractMarketChart(request)
 }
 
+func (c ClientImpl) GetContractMarketChartWithContext(ctx context.Context, request *contract.GetContractMarketChartRequest) (*contract.GetContractMarketChartResponse, error) {
+	return c.ContractClient.GetContractMarketChartWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetContractMarketChartRange(request *contract.GetContractMarketChartRangeRequest) (*contract.GetContractMarketChartRangeResponse, error) {
 	return c.ContractClient.GetContractMarketChartRange(request)
 }
 
+func (c ClientImpl) GetContractMarketChartRangeWithContext(ctx context.Context, request *contract.GetContractMarketChartRangeRequest) (*contract.GetContractMarketChartRangeResponse, error) {
+	return c.ContractClient.GetContractMarketChartRangeWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetDerivativesList() (*derivatives.GetDerivativesListResponse, error) {
 	return c.DerivativesClient.GetDerivativesList()
 }
 
+func (c ClientImpl) GetDerivativesListWithContext(ctx context.Context) (*derivatives.GetDerivativesListResponse, error) {
+	return c.DerivativesClient.GetDerivativesListWithContext(ctx)
+}
+
 func (c ClientImpl) GetDerivativesExchangesList() (*derivatives.GetDerivativesExchangesListResponse, error) {
 	return c.DerivativesClient.GetDerivativesExchangesList()
 }
 
+func (c ClientImpl) GetDerivativesExchangesListWithContext(ctx context.Context) (*derivatives.GetDerivativesExchangesListResponse, error) {
+	return c.DerivativesClient.GetDerivativesExchangesListWithContext(ctx)
+}
+
 func (c ClientImpl) GetDerivativeExchangeData(request *derivatives.GetDerivativeExchangeDataRequest) (*derivatives.GetDerivativeExchangeDataResponse, error) {
 	return c.DerivativesClient.GetDerivativeExchangeData(request)
 }
 
+func (c ClientImpl) GetDerivativeExchangeDataWithContext(ctx context.Context, request *derivatives.GetDerivativeExchangeDataRequest) (*derivatives.GetDerivativeExchangeDataResponse, error) {
+	return c.DerivativesClient.GetDerivativeExchangeDataWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetDerivativesExchangesListIDMap() (*derivatives.GetDerivativesExchangesListIDMapResponse, error) {
 	return c.DerivativesClient.GetDerivativesExchangesListIDMap()
 }
 
+func (c ClientImpl) GetDerivativesExchangesListIDMapWithContext(ctx context.Context) (*derivatives.GetDerivativesExchangesListIDMapResponse, error) {
+	return c.DerivativesClient.GetDerivativesExchangesListIDMapWithContext(ctx)
+}
+
 func (c ClientImpl) GetNFTsList() (*nfts.GetNFTsListResponse, error) {
 	return c.NFTsClient.GetNFTsList()
 }
 
+func (c ClientImpl) GetNFTsListWithContext(ctx context.Context) (*nfts.GetNFTsListResponse, error) {
+	return c.NFTsClient.GetNFTsListWithContext(ctx)
+}
+
 func (c ClientImpl) GetNFTData(request *nfts.GetNFTDataRequest) (*nfts.GetNFTDataResponse, error) {
 	return c.NFTsClient.GetNFTData(request)
 }
 
+func (c ClientImpl) GetNFTDataWithContext(ctx context.Context, request *nfts.GetNFTDataRequest) (*nfts.GetNFTDataResponse, error) {
+	return c.NFTsClient.GetNFTDataWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetNFTContractData(request *nfts.GetNFTContractDataRequest) (*nfts.GetNFTContractDataResponse, error) {
 	return c.NFTsClient.GetNFTContractData(request)
 }
 
+func (c ClientImpl) GetNFTContractDataWithContext(ctx context.Context, request *nfts.GetNFTContractDataRequest) (*nfts.GetNFTContractDataResponse, error) {
+	return c.NFTsClient.GetNFTContractDataWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetNFTsMarketData(request *nfts.GetNFTsMarketDataRequest) (*nfts.GetNFTsMarketDataResponse, error) {
 	return c.NFTsClient.GetNFTsMarketData(request)
 }
 
+func (c ClientImpl) GetNFTsMarketDataWithContext(ctx context.Context, request *nfts.GetNFTsMarketDataRequest) (*nfts.GetNFTsMarketDataResponse, error) {
+	return c.NFTsClient.GetNFTsMarketDataWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetNFTHistory(request *nfts.GetNFTHistoryRequest) (*nfts.GetNFTHistoryResponse, error) {
 	return c.NFTsClient.GetNFTHistory(request)
 }
 
+func (c ClientImpl) GetNFTHistoryWithContext(ctx context.Context, request *nfts.GetNFTHistoryRequest) (*nfts.GetNFTHistoryResponse, error) {
+	return c.NFTsClient.GetNFTHistoryWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetNFTContractHistory(request *nfts.GetNFTContractHistoryRequest) (*nfts.GetNFTContractHistoryResponse, error) {
 	return c.NFTsClient.GetNFTContractHistory(request)
 }
 
+func (c ClientImpl) GetNFTContractHistoryWithContext(ctx context.Context, request *nfts.GetNFTContractHistoryRequest) (*nfts.GetNFTContractHistoryResponse, error) {
+	return c.NFTsClient.GetNFTContractHistoryWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetNFTTickers(request *nfts.GetNFTTickersRequest) (*nfts.GetNFTTickersResponse, error) {
 	return c.NFTsClient.GetNFTTickers(request)
 }
 
+func (c ClientImpl) GetNFTTickersWithContext(ctx context.Context, request *nfts.GetNFTTickersRequest) (*nfts.GetNFTTickersResponse, error) {
+	return c.NFTsClient.GetNFTTickersWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetExchangeRates() (*exchange_rates.GetExchangeRatesResponse, error) {
 	return c.ExchangeRatesClient.GetExchangeRates()
 }
 
+func (c ClientImpl) GetExchangeRatesWithContext(ctx context.Context) (*exchange_rates.GetExchangeRatesResponse, error) {
+	return c.ExchangeRatesClient.GetExchangeRatesWithContext(ctx)
+}
+
+func (c ClientImpl) GetExchangeRate(request *exchange_rates.GetExchangeRateRequest) (*exchange_rates.GetExchangeRateResponse, error) {
+	return c.ExchangeRatesClient.GetExchangeRate(request)
+}
+
+func (c ClientImpl) GetExchangeRateWithContext(ctx context.Context, request *exchange_rates.GetExchangeRateRequest) (*exchange_rates.GetExchangeRateResponse, error) {
+	return c.ExchangeRatesClient.GetExchangeRateWithContext(ctx, request)
+}
+
 func (c ClientImpl) Search(request *search.SearchRequest) (*search.SearchResponse, error) {
 	return c.SearchClient.Search(request)
 }
 
+func (c ClientImpl) SearchWithContext(ctx context.Context, request *search.SearchRequest) (*search.SearchResponse, error) {
+	return c.SearchClient.SearchWithContext(ctx, request)
+}
+
 func (c ClientImpl) GetTrending() (*trending.TrendingResponse, error) {
 	return c.TrendingClient.GetTrending()
 }
 
+func (c ClientImpl) GetTrendingWithContext(ctx context.Context) (*trending.TrendingResponse, error) {
+	return c.TrendingClient.GetTrendingWithContext(ctx)
+}
+
 func (c ClientImpl) GetGlobal() (*global.GetGlobalResponse, error) {
 	return c.GlobalClient.GetGlobal()
 }
 
+func (c ClientImpl) GetGlobalWithContext(ctx context.Context) (*global.GetGlobalResponse, error) {
+	return c.GlobalClient.GetGlobalWithContext(ctx)
+}
+
 func (c ClientImpl) GetGlobalDefi() (*global.GetGlobalDefiResponse, error) {
 	return c.GlobalClient.GetGlobalDefi()
 }
 
+func (c ClientImpl) GetGlobalDefiWithContext(ctx context.Context) (*global.GetGlobalDefiResponse, error) {
+	return c.GlobalClient.GetGlobalDefiWithContext(ctx)
+}
+
 func (c ClientImpl) GetGlobalMarketCapChart(vsCurrency string, days string) (*global.GetGlobalMarketCapChartResponse, error) {
 	return c.GlobalClient.GetGlobalMarketCapChart(vsCurrency, days)
 }
 
+func (c ClientImpl) GetGlobalMarketCapChartWithContext(ctx context.Context, vsCurrency string, days string) (*global.GetGlobalMarketCapChartResponse, error) {
+	return c.GlobalClient.GetGlobalMarketCapChartWithContext(ctx, vsCurrency, days)
+}
+
 func (c ClientImpl) GetPublicTreasury() (*companies.GetPublicTreasuryResponse, error) {
 	return c.CompaniesClient.GetPublicTreasury()
 }
+
+func (c ClientImpl) GetPublicTreasuryWithContext(ctx context.Context) (*companies.GetPublicTreasuryResponse, error) {
+	return c.CompaniesClient.GetPublicTreasuryWithContext(ctx)
+}
+
+func (c ClientImpl) GetNetworks(request *onchain.GetNetworksRequest) (*onchain.GetNetworksResponse, error) {
+	return c.OnchainClient.GetNetworks(request)
+}
+
+func (c ClientImpl) GetNetworksWithContext(ctx context.Context, request *onchain.GetNetworksRequest) (*onchain.GetNetworksResponse, error) {
+	return c.OnchainClient.GetNetworksWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetNetworkDexes(request *onchain.GetNetworkDexesRequest) (*onchain.GetNetworkDexesResponse, error) {
+	return c.OnchainClient.GetNetworkDexes(request)
+}
+
+func (c ClientImpl) GetNetworkDexesWithContext(ctx context.Context, request *onchain.GetNetworkDexesRequest) (*onchain.GetNetworkDexesResponse, error) {
+	return c.OnchainClient.GetNetworkDexesWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetPool(request *onchain.GetPoolRequest) (*onchain.GetPoolResponse, error) {
+	return c.OnchainClient.GetPool(request)
+}
+
+func (c ClientImpl) GetPoolWithContext(ctx context.Context, request *onchain.GetPoolRequest) (*onchain.GetPoolResponse, error) {
+	return c.OnchainClient.GetPoolWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetMultiplePools(request *onchain.GetMultiplePoolsRequest) (*onchain.GetMultiplePoolsResponse, error) {
+	return c.OnchainClient.GetMultiplePools(request)
+}
+
+func (c ClientImpl) GetMultiplePoolsWithContext(ctx context.Context, request *onchain.GetMultiplePoolsRequest) (*onchain.GetMultiplePoolsResponse, error) {
+	return c.OnchainClient.GetMultiplePoolsWithContext(ctx, request)
+}
+
+func (c ClientImpl) SearchPools(request *onchain.SearchPoolsRequest) (*onchain.SearchPoolsResponse, error) {
+	return c.OnchainClient.SearchPools(request)
+}
+
+func (c ClientImpl) SearchPoolsWithContext(ctx context.Context, request *onchain.SearchPoolsRequest) (*onchain.SearchPoolsResponse, error) {
+	return c.OnchainClient.SearchPoolsWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetTrendingPools(request *onchain.GetTrendingPoolsRequest) (*onchain.GetTrendingPoolsResponse, error) {
+	return c.OnchainClient.GetTrendingPools(request)
+}
+
+func (c ClientImpl) GetTrendingPoolsWithContext(ctx context.Context, request *onchain.GetTrendingPoolsRequest) (*onchain.GetTrendingPoolsResponse, error) {
+	return c.OnchainClient.GetTrendingPoolsWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetNetworkTrendingPools(request *onchain.GetNetworkTrendingPoolsRequest) (*onchain.GetNetworkTrendingPoolsResponse, error) {
+	return c.OnchainClient.GetNetworkTrendingPools(request)
+}
+
+func (c ClientImpl) GetNetworkTrendingPoolsWithContext(ctx context.Context, request *onchain.GetNetworkTrendingPoolsRequest) (*onchain.GetNetworkTrendingPoolsResponse, error) {
+	return c.OnchainClient.GetNetworkTrendingPoolsWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetTopPools(request *onchain.GetTopPoolsRequest) (*onchain.GetTopPoolsResponse, error) {
+	return c.OnchainClient.GetTopPools(request)
+}
+
+func (c ClientImpl) GetTopPoolsWithContext(ctx context.Context, request *onchain.GetTopPoolsRequest) (*onchain.GetTopPoolsResponse, error) {
+	return c.OnchainClient.GetTopPoolsWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetDexTopPools(request *onchain.GetDexTopPoolsRequest) (*onchain.GetDexTopPoolsResponse, error) {
+	return c.OnchainClient.GetDexTopPools(request)
+}
+
+func (c ClientImpl) GetDexTopPoolsWithContext(ctx context.Context, request *onchain.GetDexTopPoolsRequest) (*onchain.GetDexTopPoolsResponse, error) {
+	return c.OnchainClient.GetDexTopPoolsWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetNewPools(request *onchain.GetNewPoolsRequest) (*onchain.GetNewPoolsResponse, error) {
+	return c.OnchainClient.GetNewPools(request)
+}
+
+func (c ClientImpl) GetNewPoolsWithContext(ctx context.Context, request *onchain.GetNewPoolsRequest) (*onchain.GetNewPoolsResponse, error) {
+	return c.OnchainClient.GetNewPoolsWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetNetworkNewPools(request *onchain.GetNetworkNewPoolsRequest) (*onchain.GetNetworkNewPoolsResponse, error) {
+	return c.OnchainClient.GetNetworkNewPools(request)
+}
+
+func (c ClientImpl) GetNetworkNewPoolsWithContext(ctx context.Context, request *onchain.GetNetworkNewPoolsRequest) (*onchain.GetNetworkNewPoolsResponse, error) {
+	return c.OnchainClient.GetNetworkNewPoolsWithContext(ctx, request)
+}
+
+func (c ClientImpl) TrendingPoolsPages(request *onchain.GetTrendingPoolsRequest) *onchain.PoolPager {
+	return c.OnchainClient.TrendingPoolsPages(request)
+}
+
+func (c ClientImpl) NetworkTrendingPoolsPages(request *onchain.GetNetworkTrendingPoolsRequest) *onchain.PoolPager {
+	return c.OnchainClient.NetworkTrendingPoolsPages(request)
+}
+
+func (c ClientImpl) TopPoolsPages(request *onchain.GetTopPoolsRequest) *onchain.PoolPager {
+	return c.OnchainClient.TopPoolsPages(request)
+}
+
+func (c ClientImpl) DexTopPoolsPages(request *onchain.GetDexTopPoolsRequest) *onchain.PoolPager {
+	return c.OnchainClient.DexTopPoolsPages(request)
+}
+
+func (c ClientImpl) NewPoolsPages(request *onchain.GetNewPoolsRequest) *onchain.PoolPager {
+	return c.OnchainClient.NewPoolsPages(request)
+}
+
+func (c ClientImpl) NetworkNewPoolsPages(request *onchain.GetNetworkNewPoolsRequest) *onchain.PoolPager {
+	return c.OnchainClient.NetworkNewPoolsPages(request)
+}
+
+func (c ClientImpl) GetPoolOHLCV(request *onchain.GetPoolOHLCVRequest) (*onchain.GetPoolOHLCVResponse, error) {
+	return c.OnchainClient.GetPoolOHLCV(request)
+}
+
+func (c ClientImpl) GetPoolOHLCVWithContext(ctx context.Context, request *onchain.GetPoolOHLCVRequest) (*onchain.GetPoolOHLCVResponse, error) {
+	return c.OnchainClient.GetPoolOHLCVWithContext(ctx, request)
+}
+
+func (c ClientImpl) PoolOHLCVPages(request *onchain.GetPoolOHLCVRequest) *onchain.OHLCVPager {
+	return c.OnchainClient.PoolOHLCVPages(request)
+}
+
+func (c ClientImpl) GetPoolTrades(request *onchain.GetPoolTradesRequest) (*onchain.GetPoolTradesResponse, error) {
+	return c.OnchainClient.GetPoolTrades(request)
+}
+
+func (c ClientImpl) GetPoolTradesWithContext(ctx context.Context, request *onchain.GetPoolTradesRequest) (*onchain.GetPoolTradesResponse, error) {
+	return c.OnchainClient.GetPoolTradesWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetToken(request *onchain.GetTokenRequest) (*onchain.GetTokenResponse, error) {
+	return c.OnchainClient.GetToken(request)
+}
+
+func (c ClientImpl) GetTokenWithContext(ctx context.Context, request *onchain.GetTokenRequest) (*onchain.GetTokenResponse, error) {
+	return c.OnchainClient.GetTokenWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetMultipleTokens(request *onchain.GetMultipleTokensRequest) (*onchain.GetMultipleTokensResponse, error) {
+	return c.OnchainClient.GetMultipleTokens(request)
+}
+
+func (c ClientImpl) GetMultipleTokensWithContext(ctx context.Context, request *onchain.GetMultipleTokensRequest) (*onchain.GetMultipleTokensResponse, error) {
+	return c.OnchainClient.GetMultipleTokensWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetTokenInfo(request *onchain.GetTokenInfoRequest) (*onchain.GetTokenInfoResponse, error) {
+	return c.OnchainClient.GetTokenInfo(request)
+}
+
+func (c ClientImpl) GetTokenInfoWithContext(ctx context.Context, request *onchain.GetTokenInfoRequest) (*onchain.GetTokenInfoResponse, error) {
+	return c.OnchainClient.GetTokenInfoWithContext(ctx, request)
+}
+
+func (c ClientImpl) GetTokenPools(request *onchain.GetTokenPoolsRequest) (*onchain.GetTokenPoolsResponse, error) {
+	return c.OnchainClient.GetTokenPools(request)
+}
+
+func (c ClientImpl) GetTokenPoolsWithContext(ctx context.Context, request *onchain.GetTokenPoolsRequest) (*onchain.GetTokenPoolsResponse, error) {
+	return c.OnchainClient.GetTokenPoolsWithContext(ctx, request)
+}
+
+func (c ClientImpl) TokenPoolsPages(request *onchain.GetTokenPoolsRequest) *onchain.PoolPager {
+	return c.OnchainClient.TokenPoolsPages(request)
+}
+
+func (c ClientImpl) GetTokenPrice(request *onchain.GetTokenPriceRequest) (*onchain.GetTokenPriceResponse, error) {
+	return c.OnchainClient.GetTokenPrice(request)
+}
+
+func (c ClientImpl) GetTokenPriceWithContext(ctx context.Context, request *onchain.GetTokenPriceRequest) (*onchain.GetTokenPriceResponse, error) {
+	return c.OnchainClient.GetTokenPriceWithContext(ctx, request)
+}
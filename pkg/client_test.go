@@ -0,0 +1,205 @@
+package pkg_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ipangpang/coingecko-v3/pkg"
+	"github.com/ipangpang/coingecko-v3/pkg/coingeckotest"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/coins"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/ping"
+)
+
+// newTestServer returns a fake API closed at the end of the test
+func newTestServer(t *testing.T) *coingeckotest.Server {
+	t.Helper()
+
+	server := coingeckotest.NewServer()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestErrorMapping(t *testing.T) {
+	tests := []struct {
+		name             string
+		fault            coingeckotest.Fault
+		wantNotFound     bool
+		wantRateLimited  bool
+		wantUnauthorized bool
+	}{
+		{
+			name:         "unknown coin",
+			fault:        coingeckotest.Fault{StatusCode: http.StatusNotFound, Body: `{"error":"coin not found"}`},
+			wantNotFound: true,
+		},
+		{
+			name:            "rate limited",
+			fault:           coingeckotest.RateLimitFault(0, 1),
+			wantRateLimited: true,
+		},
+		{
+			name:            "rate limit error code",
+			fault:           coingeckotest.Fault{StatusCode: http.StatusBadRequest, Body: `{"status":{"error_code":429,"error_message":"You've exceeded the Rate Limit"}}`},
+			wantRateLimited: true,
+		},
+		{
+			name:             "missing API key",
+			fault:            coingeckotest.Fault{StatusCode: http.StatusUnauthorized, Body: `{"status":{"error_code":10002,"error_message":"API Key Missing"}}`},
+			wantUnauthorized: true,
+		},
+		{
+			name:  "server error",
+			fault: coingeckotest.ServerErrorFault(1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t)
+			server.InjectFault(coins.GetCoinDataByIDEndpoint, tt.fault)
+			client := pkg.NewClient(pkg.WithBaseURL(server.URL()), pkg.WithRetryPolicy(base.NoRetryPolicy()))
+
+			_, err := client.GetCoinDataByID(&coins.GetCoinDataByIDRequest{ID: "unknown"})
+			if _, ok := base.AsAPIError(err); !ok {
+				t.Fatalf("error = %v, want an API error", err)
+			}
+			if got := base.IsNotFound(err); got != tt.wantNotFound {
+				t.Errorf("IsNotFound = %v, want %v", got, tt.wantNotFound)
+			}
+			if got := base.IsRateLimited(err); got != tt.wantRateLimited {
+				t.Errorf("IsRateLimited = %v, want %v", got, tt.wantRateLimited)
+			}
+			if got := base.IsUnauthorized(err); got != tt.wantUnauthorized {
+				t.Errorf("IsUnauthorized = %v, want %v", got, tt.wantUnauthorized)
+			}
+		})
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	server := newTestServer(t)
+	server.InjectFault(ping.PingEndpoint, coingeckotest.RateLimitFault(time.Second, 1))
+
+	policy := base.DefaultRetryPolicy()
+	policy.InitialWait = time.Millisecond
+	policy.Jitter = 0
+	var waits []time.Duration
+	policy.OnRetry = func(attempt base.RetryAttempt) {
+		waits = append(waits, attempt.Wait)
+	}
+	client := pkg.NewClient(pkg.WithBaseURL(server.URL()), pkg.WithRetryPolicy(policy))
+
+	start := time.Now()
+	if _, err := client.Ping(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := server.Calls(ping.PingEndpoint); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+	if len(waits) != 1 || waits[0] != time.Second {
+		t.Errorf("retry waits = %v, want the 1s of Retry-After", waits)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least 1s", elapsed)
+	}
+
+	// Without retries the wait is reported on the error
+	server.InjectFault(ping.PingEndpoint, coingeckotest.RateLimitFault(2*time.Second, 1))
+	_, err := pkg.NewClient(pkg.WithBaseURL(server.URL()), pkg.WithRetryPolicy(base.NoRetryPolicy())).Ping()
+	if apiErr, ok := base.AsAPIError(err); !ok || !base.IsRateLimited(err) || apiErr.RetryAfter != 2*time.Second {
+		t.Errorf("error = %v, want a rate limit error retrying after 2s", err)
+	}
+}
+
+// redirectTransport sends every request to server, recording the URL and header it was sent with
+type redirectTransport struct {
+	server *coingeckotest.Server
+	urls   []*url.URL
+	header http.Header
+}
+
+func (r *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.urls = append(r.urls, req.URL)
+	r.header = req.Header.Clone()
+
+	target, err := url.Parse(r.server.URL())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.URL.Scheme = target.Scheme
+	req.URL.Host = target.Host
+	req.Host = ""
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestAPIKeyPlacement(t *testing.T) {
+	tests := []struct {
+		name       string
+		option     pkg.ClientOption
+		wantHost   string
+		wantHeader string
+		wantQuery  string
+	}{
+		{
+			name:       "pro key",
+			option:     pkg.WithProAPIKey("pro-key"),
+			wantHost:   "pro-api.coingecko.com",
+			wantHeader: base.ProAPIKeyHeader,
+		},
+		{
+			name:       "demo key",
+			option:     pkg.WithDemoAPIKey("demo-key"),
+			wantHost:   "api.coingecko.com",
+			wantHeader: base.DemoAPIKeyHeader,
+		},
+		{
+			name:      "pro key in query",
+			option:    pkg.WithAPIKeyInQuery("pro-key", base.APIKeyTypePro),
+			wantHost:  "pro-api.coingecko.com",
+			wantQuery: base.ProAPIKeyQueryParam,
+		},
+		{
+			name:      "demo key in query",
+			option:    pkg.WithAPIKeyInQuery("demo-key", base.APIKeyTypeDemo),
+			wantHost:  "api.coingecko.com",
+			wantQuery: base.DemoAPIKeyQueryParam,
+		},
+	}
+
+	keyParams := []string{base.ProAPIKeyQueryParam, base.DemoAPIKeyQueryParam}
+	keyHeaders := []string{base.ProAPIKeyHeader, base.DemoAPIKeyHeader}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &redirectTransport{server: newTestServer(t)}
+			client := pkg.NewClient(tt.option, pkg.WithTransport(transport), pkg.WithRetryPolicy(base.NoRetryPolicy()))
+
+			if _, err := client.Ping(); err != nil {
+				t.Fatal(err)
+			}
+			if len(transport.urls) != 1 {
+				t.Fatalf("sent %d requests, want 1", len(transport.urls))
+			}
+
+			sent := transport.urls[0]
+			if sent.Host != tt.wantHost || sent.Path != "/api/v3/ping" {
+				t.Errorf("URL = %s, want https://%s/api/v3/ping", sent, tt.wantHost)
+			}
+			for _, param := range keyParams {
+				if got, want := sent.Query().Has(param), param == tt.wantQuery; got != want {
+					t.Errorf("query parameter %s sent = %v, want %v", param, got, want)
+				}
+			}
+			for _, header := range keyHeaders {
+				if got, want := transport.header.Get(header) != "", header == tt.wantHeader; got != want {
+					t.Errorf("header %s sent = %v, want %v", header, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package coingeckotest
+
+import (
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/asset_platforms"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/categories"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/coins"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/companies"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/contract"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/derivatives"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/exchange_rates"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/exchanges"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/global"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/key"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/nfts"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/onchain"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/ping"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/search"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/simple"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/trending"
+)
+
+// endpoints are the path templates served by the fake API
+var endpoints = []string{
+	ping.PingEndpoint,
+	key.KeyEndpoint,
+
+	simple.GetCoinPriceByIDsEndpoint,
+	simple.GetCoinPriceByTokenAddressEndpoint,
+	simple.GetSupportedCurrenciesEndpoint,
+
+	coins.GetCoinsListEndpoint,
+	coins.GetTopGainersAndLosersEndpoint,
+	coins.GetRecentlyAddedCoinsEndpoint,
+	coins.GetCoinsListWithMarketDataEndpoint,
+	coins.GetCoinDataByIDEndpoint,
+	coins.GetCoinTickersByIDEndpoint,
+	coins.GetCoinHistoryByIDEndpoint,
+	coins.GetCoinMarketChartByIDEndpoint,
+	coins.GetCoinMarketChartRangeEndpoint,
+	coins.GetCoinOHLCByIDEndpoint,
+	coins.GetCoinOHLCRangeEndpoint,
+	coins.GetCoinCirculatingSupplyChartEndpoint,
+	coins.GetCoinCirculatingSupplyChartRangeEndpoint,
+	coins.GetCoinTotalSupplyChartEndpoint,
+	coins.GetCoinTotalSupplyChartRangeEndpoint,
+
+	contract.GetContractDataEndpoint,
+	contract.GetContractMarketChartEndpoint,
+	contract.GetContractMarketChartRangeEndpoint,
+
+	asset_platforms.GetAssetPlatformsEndpoint,
+	asset_platforms.GetTokenListsByAssetPlatformIDEndpoint,
+
+	categories.GetCategoriesListRequestPoint,
+	categories.GetCategoriesDataRequestPoint,
+
+	exchanges.GetExchangesListRequestPoint,
+	exchanges.GetExchangesListIDRequestPoint,
+	exchanges.GetExchangeDataRequestPoint,
+	exchanges.GetExchangeTickersRequestPoint,
+	exchanges.GetExchangeVolumeChartRequestPoint,
+
+	derivatives.GetDerivativesListEndpoint,
+	derivatives.GetDerivativesExchangesListEndpoint,
+	derivatives.GetDerivativeExchangeDataEndpoint,
+	derivatives.GetDerivativesExchangesListIDEndpoint,
+
+	nfts.GetNFTsListEndpoint,
+	nfts.GetNFTDataEndpoint,
+	nfts.GetNFTContractDataEndpoint,
+	nfts.GetNFTsMarketDataEndpoint,
+	nfts.GetNFTHistoryEndpoint,
+	nfts.GetNFTContractHistoryEndpoint,
+	nfts.GetNFTTickersEndpoint,
+
+	exchange_rates.GetExchangeRatesRequestPoint,
+	exchange_rates.GetExchangeRateEndpoint,
+
+	search.SearchEndpoint,
+	trending.GetTrendingEndpoint,
+	global.GetGlobalRequestPoint,
+	global.GetGlobalDefiRequestPoint,
+	global.GetGlobalMarketCapChartRequestPoint,
+	companies.GetPublicTreasuryEndpoint,
+
+	onchain.GetNetworksEndpoint,
+	onchain.GetNetworkDexesEndpoint,
+	onchain.GetPoolEndpoint,
+	onchain.GetMultiplePoolsEndpoint,
+	onchain.SearchPoolsEndpoint,
+	onchain.GetTrendingPoolsEndpoint,
+	onchain.GetNetworkTrendingPoolsEndpoint,
+	onchain.GetTopPoolsEndpoint,
+	onchain.GetDexTopPoolsEndpoint,
+	onchain.GetNewPoolsEndpoint,
+	onchain.GetNetworkNewPoolsEndpoint,
+	onchain.GetPoolOHLCVEndpoint,
+	onchain.GetPoolTradesEndpoint,
+	onchain.GetTokenEndpoint,
+	onchain.GetMultipleTokensEndpoint,
+	onchain.GetTokenInfoEndpoint,
+	onchain.GetTokenPoolsEndpoint,
+	onchain.GetTokenPriceEndpoint,
+}
+
+// Endpoints returns the path templates of every endpoint served by the fake API
+func Endpoints() []string {
+	return append([]string(nil), endpoints...)
+}
@@ -0,0 +1,385 @@
+// Package coingeckotest provides an in-process fake CoinGecko API for tests.
+//
+// The server answers every endpoint of the client from editable JSON fixtures,
+// can inject faults and latency, and counts calls per endpoint:
+//
+//	server := coingeckotest.NewServer()
+//	defer server.Close()
+//
+//	server.InjectFault(coins.GetCoinDataByIDEndpoint, coingeckotest.RateLimitFault(time.Second, 1))
+//	client := pkg.NewClient(pkg.WithBaseURL(server.URL()))
+//
+// Endpoints are identified by the path templates of the endpoint packages, e.g.
+// coins.GetCoinMarketChartByIDEndpoint ("/coins/{id}/market_chart").
+package coingeckotest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AnyEndpoint applies faults and latency to every endpoint
+const AnyEndpoint = "*"
+
+//go:embed fixtures/*.json
+var defaultFixtures embed.FS
+
+// Fault is an error injected into the responses of an endpoint
+type Fault struct {
+	// StatusCode is the status code of the response, e.g. 429 or 500
+	StatusCode int
+	// Body is the response body, a CoinGecko style error body when empty
+	Body string
+	// RetryAfter sets the Retry-After header when positive
+	RetryAfter time.Duration
+	// Timeout makes the server never answer, until the client gives up or the server is closed
+	Timeout bool
+	// Times is the number of requests affected, 0 for every request until ClearFaults
+	Times int
+}
+
+// RateLimitFault returns a fault answering times requests with 429 Too Many Requests
+func RateLimitFault(retryAfter time.Duration, times int) Fault {
+	return Fault{StatusCode: http.StatusTooManyRequests, RetryAfter: retryAfter, Times: times}
+}
+
+// ServerErrorFault returns a fault answering times requests with 500 Internal Server Error
+func ServerErrorFault(times int) Fault {
+	return Fault{StatusCode: http.StatusInternalServerError, Times: times}
+}
+
+// TimeoutFault returns a fault leaving times requests unanswered
+func TimeoutFault(times int) Fault {
+	return Fault{Timeout: true, Times: times}
+}
+
+// route is an endpoint path template split into segments
+type route struct {
+	endpoint string
+	segments []string
+	literals int
+}
+
+// match reports whether path segments match the route
+func (r *route) match(segments []string) bool {
+	if len(segments) != len(r.segments) {
+		return false
+	}
+	for i, segment := range r.segments {
+		if !isParam(segment) && segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Server is a fake CoinGecko API served by an httptest.Server
+type Server struct {
+	server  *httptest.Server
+	routes  []*route
+	closing chan struct{}
+	once    sync.Once
+
+	mu       sync.Mutex
+	fixtures map[string][]byte
+	handlers map[string]http.HandlerFunc
+	faults   map[string]*Fault
+	latency  map[string]time.Duration
+	calls    map[string]int
+}
+
+// NewServer starts a fake API serving the default fixtures
+func NewServer() *Server {
+	s := &Server{
+		closing:  make(chan struct{}),
+		fixtures: make(map[string][]byte),
+		handlers: make(map[string]http.HandlerFunc),
+		faults:   make(map[string]*Fault),
+		latency:  make(map[string]time.Duration),
+		calls:    make(map[string]int),
+	}
+
+	for _, endpoint := range Endpoints() {
+		segments := splitPath(endpoint)
+		r := &route{endpoint: endpoint, segments: segments}
+		for _, segment := range segments {
+			if !isParam(segment) {
+				r.literals++
+			}
+		}
+		s.routes = append(s.routes, r)
+
+		if data, ok := DefaultFixture(endpoint); ok {
+			s.fixtures[endpoint] = data
+		}
+	}
+	// Prefer the most specific route, e.g. "/coins/list" over "/coins/{id}"
+	sort.SliceStable(s.routes, func(i, j int) bool {
+		return s.routes[i].literals > s.routes[j].literals
+	})
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL returns the base URL of the server, to be passed to WithBaseURL
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close releases requests left unanswered by timeout faults and shuts the server down
+func (s *Server) Close() {
+	s.once.Do(func() {
+		close(s.closing)
+	})
+	s.server.Close()
+}
+
+// FixtureFileName returns the name of the fixture file of an endpoint,
+// e.g. "coins.{id}.market_chart.json" for "/coins/{id}/market_chart"
+func FixtureFileName(endpoint string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(endpoint, "/"), "/", ".") + ".json"
+}
+
+// DefaultFixture returns the default response body of an endpoint
+func DefaultFixture(endpoint string) ([]byte, bool) {
+	data, err := defaultFixtures.ReadFile("fixtures/" + FixtureFileName(endpoint))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// SetFixture sets the response body of an endpoint
+func (s *Server) SetFixture(endpoint string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fixtures[endpoint] = body
+}
+
+// SetFixtureJSON sets the response body of an endpoint to the JSON encoding of v
+func (s *Server) SetFixtureJSON(endpoint string, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	s.SetFixture(endpoint, body)
+	return nil
+}
+
+// Fixture returns the response body of an endpoint
+func (s *Server) Fixture(endpoint string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.fixtures[endpoint]
+}
+
+// LoadFixtures replaces fixtures with the files of dir named by FixtureFileName
+func (s *Server) LoadFixtures(dir string) error {
+	for _, endpoint := range Endpoints() {
+		data, err := os.ReadFile(filepath.Join(dir, FixtureFileName(endpoint)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read fixture: %w", err)
+		}
+		s.SetFixture(endpoint, data)
+	}
+	return nil
+}
+
+// Handle serves an endpoint with handler instead of its fixture, e.g. to vary responses by parameters
+func (s *Server) Handle(endpoint string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[endpoint] = handler
+}
+
+// InjectFault makes an endpoint, or AnyEndpoint, answer with fault
+func (s *Server) InjectFault(endpoint string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults[endpoint] = &fault
+}
+
+// ClearFaults removes every injected fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = make(map[string]*Fault)
+}
+
+// SetLatency delays the responses of an endpoint, or AnyEndpoint, by latency
+func (s *Server) SetLatency(endpoint string, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency[endpoint] = latency
+}
+
+// Calls returns the number of requests received by an endpoint, including failed ones
+func (s *Server) Calls(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[endpoint]
+}
+
+// TotalCalls returns the number of requests received by every endpoint
+func (s *Server) TotalCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total := 0
+	for _, calls := range s.calls {
+		total += calls
+	}
+	return total
+}
+
+// ResetCalls resets the call counts of every endpoint
+func (s *Server) ResetCalls() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = make(map[string]int)
+}
+
+// serveHTTP routes a request to its endpoint
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	// Accept base URLs ending with the API version, like the real hosts
+	path := strings.TrimPrefix(r.URL.Path, "/api/v3")
+
+	endpoint := s.route(path)
+	if endpoint == "" {
+		writeError(w, http.StatusNotFound, "")
+		return
+	}
+
+	s.mu.Lock()
+	s.calls[endpoint]++
+	latency := s.latency[endpoint] + s.latency[AnyEndpoint]
+	fault := s.takeFault(endpoint)
+	handler := s.handlers[endpoint]
+	body := s.fixtures[endpoint]
+	s.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		case <-s.closing:
+			return
+		}
+	}
+
+	if fault != nil {
+		if fault.Timeout {
+			select {
+			case <-r.Context().Done():
+			case <-s.closing:
+			}
+			return
+		}
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int((fault.RetryAfter+time.Second-1)/time.Second)))
+		}
+		if fault.Body != "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(fault.StatusCode)
+			_, _ = w.Write([]byte(fault.Body))
+			return
+		}
+		writeError(w, fault.StatusCode, "")
+		return
+	}
+
+	if handler != nil {
+		handler(w, r)
+		return
+	}
+	if body == nil {
+		writeError(w, http.StatusNotImplemented, "no fixture for "+endpoint)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// route returns the endpoint matching path, or "" when none does
+func (s *Server) route(path string) string {
+	segments := splitPath(path)
+	for _, r := range s.routes {
+		if r.match(segments) {
+			return r.endpoint
+		}
+	}
+	return ""
+}
+
+// takeFault returns the fault applying to the next request of endpoint, consuming one of its times.
+// s.mu must be held.
+func (s *Server) takeFault(endpoint string) *Fault {
+	for _, key := range []string{endpoint, AnyEndpoint} {
+		fault, ok := s.faults[key]
+		if !ok {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				delete(s.faults, key)
+			}
+		}
+		f := *fault
+		return &f
+	}
+	return nil
+}
+
+// writeError writes an error in the format of the CoinGecko API
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	if message == "" {
+		message = http.StatusText(statusCode)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": map[string]interface{}{
+			"error_code":    statusCode,
+			"error_message": message,
+		},
+	})
+}
+
+// splitPath splits a path into its segments
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// isParam reports whether a path template segment is a parameter, e.g. "{id}"
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
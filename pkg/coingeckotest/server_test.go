@@ -0,0 +1,279 @@
+package coingeckotest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// concretePath fills the parameters of an endpoint template, e.g. "/coins/{id}" becomes "/coins/test-id"
+func concretePath(endpoint string) string {
+	segments := splitPath(endpoint)
+	for i, segment := range segments {
+		if isParam(segment) {
+			segments[i] = "test-" + strings.Trim(segment, "{}")
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// get sends a GET request to the server and returns the status code, header and body of the response
+func get(t *testing.T, s *Server, path string) (int, http.Header, []byte) {
+	t.Helper()
+
+	resp, err := http.Get(s.URL() + path)
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	return resp.StatusCode, resp.Header, body
+}
+
+func TestServerRoutesEveryEndpointToItsFixture(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	for _, endpoint := range Endpoints() {
+		t.Run(endpoint, func(t *testing.T) {
+			fixture, ok := DefaultFixture(endpoint)
+			if !ok {
+				t.Fatalf("no default fixture %s", FixtureFileName(endpoint))
+			}
+
+			path := concretePath(endpoint)
+			if got := s.route(path); got != endpoint {
+				t.Fatalf("%s routed to %q", path, got)
+			}
+
+			before := s.Calls(endpoint)
+			status, header, body := get(t, s, path)
+			if status != http.StatusOK {
+				t.Fatalf("GET %s: status %d: %s", path, status, body)
+			}
+			if got := header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("GET %s: Content-Type %q", path, got)
+			}
+			if !bytes.Equal(body, fixture) {
+				t.Errorf("GET %s: body is not the fixture of %s", path, endpoint)
+			}
+			if got := s.Calls(endpoint); got != before+1 {
+				t.Errorf("Calls(%s) = %d, want %d", endpoint, got, before+1)
+			}
+		})
+	}
+
+	if got, want := s.TotalCalls(), len(Endpoints()); got != want {
+		t.Errorf("TotalCalls() = %d, want %d", got, want)
+	}
+}
+
+func TestServerPrefersSpecificRoutes(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/coins/list", want: "/coins/list"},
+		{path: "/coins/markets", want: "/coins/markets"},
+		{path: "/coins/bitcoin", want: "/coins/{id}"},
+		{path: "/onchain/networks/trending_pools", want: "/onchain/networks/trending_pools"},
+		{path: "/onchain/networks/new_pools", want: "/onchain/networks/new_pools"},
+		{path: "/onchain/networks/eth/pools/multi/0xa,0xb", want: "/onchain/networks/{network}/pools/multi/{addresses}"},
+		{path: "/onchain/networks/eth/tokens/multi/0xa,0xb", want: "/onchain/networks/{network}/tokens/multi/{addresses}"},
+		{path: "/onchain/networks/eth/tokens/0xa/pools", want: "/onchain/networks/{network}/tokens/{address}/pools"},
+		{path: "/unknown", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := s.route(tt.path); got != tt.want {
+			t.Errorf("route(%s) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestServerAcceptsVersionPrefixAndRejectsUnknownPaths(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	if status, _, body := get(t, s, "/api/v3/ping"); status != http.StatusOK {
+		t.Errorf("GET /api/v3/ping: status %d: %s", status, body)
+	}
+	if status, _, _ := get(t, s, "/unknown/path"); status != http.StatusNotFound {
+		t.Errorf("GET /unknown/path: status %d, want 404", status)
+	}
+}
+
+func TestFixtureFileName(t *testing.T) {
+	tests := map[string]string{
+		"/ping":                    "ping.json",
+		"/coins/{id}/market_chart": "coins.{id}.market_chart.json",
+		"/onchain/networks/{network}/pools/{pool}/ohlcv/{timeframe}": "onchain.networks.{network}.pools.{pool}.ohlcv.{timeframe}.json",
+	}
+	for endpoint, want := range tests {
+		if got := FixtureFileName(endpoint); got != want {
+			t.Errorf("FixtureFileName(%s) = %s, want %s", endpoint, got, want)
+		}
+	}
+}
+
+func TestServerFixturesAndHandlers(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.SetFixture("/ping", []byte(`{"gecko_says":"custom"}`))
+	if _, _, body := get(t, s, "/ping"); string(body) != `{"gecko_says":"custom"}` {
+		t.Errorf("body = %s, want the custom fixture", body)
+	}
+
+	s.Handle("/ping", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"gecko_says":"handled"}`))
+	})
+	if _, _, body := get(t, s, "/ping"); string(body) != `{"gecko_says":"handled"}` {
+		t.Errorf("body = %s, want the handler response", body)
+	}
+	if got := s.Calls("/ping"); got != 2 {
+		t.Errorf("Calls = %d, want 2", got)
+	}
+
+	s.ResetCalls()
+	if got := s.TotalCalls(); got != 0 {
+		t.Errorf("TotalCalls after ResetCalls = %d, want 0", got)
+	}
+}
+
+func TestServerFaultTimes(t *testing.T) {
+	tests := []struct {
+		name       string
+		endpoint   string
+		fault      Fault
+		wantStatus []int
+	}{
+		{
+			name:       "rate limited twice",
+			endpoint:   "/ping",
+			fault:      RateLimitFault(2*time.Second, 2),
+			wantStatus: []int{429, 429, 200, 200},
+		},
+		{
+			name:       "server error once",
+			endpoint:   "/ping",
+			fault:      ServerErrorFault(1),
+			wantStatus: []int{500, 200},
+		},
+		{
+			name:       "every request until cleared",
+			endpoint:   "/ping",
+			fault:      ServerErrorFault(0),
+			wantStatus: []int{500, 500, 500, 500},
+		},
+		{
+			name:       "any endpoint",
+			endpoint:   AnyEndpoint,
+			fault:      ServerErrorFault(3),
+			wantStatus: []int{500, 500, 500, 200},
+		},
+		{
+			name:       "custom body",
+			endpoint:   "/ping",
+			fault:      Fault{StatusCode: http.StatusBadRequest, Body: `{"error":"invalid"}`, Times: 1},
+			wantStatus: []int{400, 200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer()
+			defer s.Close()
+
+			s.InjectFault(tt.endpoint, tt.fault)
+			for i, want := range tt.wantStatus {
+				status, header, body := get(t, s, "/ping")
+				if status != want {
+					t.Fatalf("request %d: status %d, want %d", i, status, want)
+				}
+				if status == http.StatusTooManyRequests && header.Get("Retry-After") != "2" {
+					t.Errorf("request %d: Retry-After %q, want 2", i, header.Get("Retry-After"))
+				}
+				if tt.fault.Body != "" && status == tt.fault.StatusCode && string(body) != tt.fault.Body {
+					t.Errorf("request %d: body %s, want %s", i, body, tt.fault.Body)
+				}
+			}
+			if got := s.Calls("/ping"); got != len(tt.wantStatus) {
+				t.Errorf("Calls = %d, want %d including failed requests", got, len(tt.wantStatus))
+			}
+
+			s.ClearFaults()
+			if status, _, _ := get(t, s, "/ping"); status != http.StatusOK {
+				t.Errorf("status after ClearFaults = %d, want 200", status)
+			}
+		})
+	}
+}
+
+func TestServerEndpointFaultTakesPrecedence(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.InjectFault(AnyEndpoint, ServerErrorFault(0))
+	s.InjectFault("/ping", RateLimitFault(0, 1))
+
+	wantStatus := []int{429, 500}
+	for i, want := range wantStatus {
+		if status, _, _ := get(t, s, "/ping"); status != want {
+			t.Errorf("request %d: status %d, want %d", i, status, want)
+		}
+	}
+	if status, _, _ := get(t, s, "/key"); status != http.StatusInternalServerError {
+		t.Errorf("other endpoint: status %d, want 500", status)
+	}
+}
+
+func TestServerTimeoutFault(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.InjectFault("/ping", TimeoutFault(1))
+
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	if resp, err := client.Get(s.URL() + "/ping"); err == nil {
+		resp.Body.Close()
+		t.Fatal("request answered, want a timeout")
+	}
+	if status, _, _ := get(t, s, "/ping"); status != http.StatusOK {
+		t.Errorf("status after the timeout fault = %d, want 200", status)
+	}
+}
+
+func TestServerLatency(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.SetLatency("/ping", 50*time.Millisecond)
+
+	start := time.Now()
+	if status, _, _ := get(t, s, "/ping"); status != http.StatusOK {
+		t.Fatalf("status %d, want 200", status)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("response after %s, want at least 50ms", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, s.URL()+"/ping", nil)
+	if resp, err := http.DefaultClient.Do(req); err == nil {
+		resp.Body.Close()
+		t.Error("request answered before its latency, want a timeout")
+	}
+}
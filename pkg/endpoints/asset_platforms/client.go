@@ -1,7 +1,9 @@
 package asset_platforms
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 )
@@ -11,23 +13,38 @@ const (
 	GetTokenListsByAssetPlatformIDEndpoint = "/asset_platforms/{asset_platform_id}/contract"
 )
 
-type Client struct {
+// Default cache TTLs of endpoints whose data rarely changes
+func init() {
+	base.RegisterCacheTTL(GetAssetPlatformsEndpoint, 24*time.Hour)
+	base.RegisterCacheTTL(GetTokenListsByAssetPlatformIDEndpoint, 24*time.Hour)
+}
+
+type Client interface {
+	GetAssetPlatforms() (*GetAssetPlatformsResponse, error)
+	GetAssetPlatformsWithContext(ctx context.Context) (*GetAssetPlatformsResponse, error)
+	GetTokenListsByAssetPlatformID(request *GetTokenListsByAssetPlatformIDRequest) (*GetTokenListsByAssetPlatformIDResponse, error)
+	GetTokenListsByAssetPlatformIDWithContext(ctx context.Context, request *GetTokenListsByAssetPlatformIDRequest) (*GetTokenListsByAssetPlatformIDResponse, error)
+}
+
+type ClientImpl struct {
 	baseClient *base.BaseClient
 }
 
 func NewClient(baseClient *base.BaseClient) Client {
-	return Client{
+	return &ClientImpl{
 		baseClient: baseClient,
 	}
 }
 
-func (c *Client) GetAssetPlatforms() (*GetAssetPlatformsResponse, error) {
+func (c *ClientImpl) GetAssetPlatforms() (*GetAssetPlatformsResponse, error) {
+	return c.GetAssetPlatformsWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetAssetPlatformsWithContext(ctx context.Context) (*GetAssetPlatformsResponse, error) {
 	var response GetAssetPlatformsResponse
 
-	err := c.baseClient.Get(GetAssetPlatformsEndpoint, &base.RequestOptions{
-		QueryParams: map[string]string{
-			"timeout": "10s",
-		},
+	err := c.baseClient.GetWithContext(ctx, GetAssetPlatformsEndpoint, &base.RequestOptions{
+		Operation: "asset_platforms.GetAssetPlatforms",
 	}, &response)
 
 	if err != nil {
@@ -37,20 +54,22 @@ func (c *Client) GetAssetPlatforms() (*GetAssetPlatformsResponse, error) {
 	return &response, nil
 }
 
-func (c *Client) GetTokenListsByAssetPlatformID(request *GetTokenListsByAssetPlatformIDRequest) (*GetTokenListsByAssetPlatformIDResponse, error) {
+func (c *ClientImpl) GetTokenListsByAssetPlatformID(request *GetTokenListsByAssetPlatformIDRequest) (*GetTokenListsByAssetPlatformIDResponse, error) {
+	return c.GetTokenListsByAssetPlatformIDWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetTokenListsByAssetPlatformIDWithContext(ctx context.Context, request *GetTokenListsByAssetPlatformIDRequest) (*GetTokenListsByAssetPlatformIDResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
 	var response GetTokenListsByAssetPlatformIDResponse
 
-	err := c.baseClient.Get(GetTokenListsByAssetPlatformIDEndpoint, &base.RequestOptions{
+	err := c.baseClient.GetWithContext(ctx, GetTokenListsByAssetPlatformIDEndpoint, &base.RequestOptions{
+		Operation: "asset_platforms.GetTokenListsByAssetPlatformID",
 		PathParams: map[string]string{
 			"asset_platform_id": request.AssetPlatformID,
 		},
-		QueryParams: map[string]string{
-			"timeout": "10s",
-		},
 	}, &response)
 
 	if err != nil {
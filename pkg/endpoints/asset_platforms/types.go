@@ -1,6 +1,6 @@
 package asset_platforms
 
-import "github.com/go-playground/validator/v10"
+import "github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 
 // GetAssetPlatformsResponse represents the response from the Asset Platforms List API
 type GetAssetPlatformsResponse []AssetPlatform
@@ -40,6 +40,5 @@ type Token struct {
 
 // Validate validates the request parameters
 func (r *GetTokenListsByAssetPlatformIDRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
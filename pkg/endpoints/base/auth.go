@@ -0,0 +1,97 @@
+package base
+
+import (
+	"strings"
+
+	"resty.dev/v3"
+)
+
+// API hosts
+const (
+	// PublicBaseURL is the base URL used by the public and Demo API
+	PublicBaseURL = "https://api.coingecko.com/api/v3"
+	// ProBaseURL is the base URL used by paid plans
+	ProBaseURL = "https://pro-api.coingecko.com/api/v3"
+)
+
+// API key headers and query parameters
+const (
+	DemoAPIKeyHeader     = "x-cg-demo-api-key"
+	ProAPIKeyHeader      = "x-cg-pro-api-key"
+	DemoAPIKeyQueryParam = "x_cg_demo_api_key"
+	ProAPIKeyQueryParam  = "x_cg_pro_api_key"
+)
+
+// APIKeyType defines which CoinGecko API an API key belongs to
+type APIKeyType string
+
+const (
+	// APIKeyTypeDemo is a key of the free Demo plan, used against the public host
+	APIKeyTypeDemo APIKeyType = "demo"
+	// APIKeyTypePro is a key of a paid plan, used against the Pro host
+	APIKeyTypePro APIKeyType = "pro"
+)
+
+// Header returns the request header carrying keys of this type
+func (t APIKeyType) Header() string {
+	if t == APIKeyTypeDemo {
+		return DemoAPIKeyHeader
+	}
+	return ProAPIKeyHeader
+}
+
+// QueryParam returns the query parameter carrying keys of this type
+func (t APIKeyType) QueryParam() string {
+	if t == APIKeyTypeDemo {
+		return DemoAPIKeyQueryParam
+	}
+	return ProAPIKeyQueryParam
+}
+
+// BaseURL returns the API host serving keys of this type
+func (t APIKeyType) BaseURL() string {
+	if t == APIKeyTypeDemo {
+		return PublicBaseURL
+	}
+	return ProBaseURL
+}
+
+// isDefaultBaseURL reports whether url is one of the CoinGecko hosts rather than a custom one
+func isDefaultBaseURL(url string) bool {
+	url = strings.TrimRight(url, "/")
+	return url == "" || url == PublicBaseURL || url == ProBaseURL
+}
+
+// resolveBaseURL returns the configured base URL, replacing a CoinGecko host
+// with the one matching the type of the configured API key or key pool
+func resolveBaseURL(config *Config) string {
+	if (config.APIKey != "" || config.APIKeyPool != nil) && isDefaultBaseURL(config.BaseURL) {
+		return apiKeyType(config).BaseURL()
+	}
+	if config.BaseURL == "" {
+		return PublicBaseURL
+	}
+	return config.BaseURL
+}
+
+// apiKeyType returns the configured API key type, defaulting to APIKeyTypePro
+func apiKeyType(config *Config) APIKeyType {
+	if config.APIKeyType == "" {
+		return APIKeyTypePro
+	}
+	return config.APIKeyType
+}
+
+// applyAPIKey configures client to authenticate with the configured API key
+func applyAPIKey(client *resty.Client, config *Config) {
+	if config.APIKey == "" {
+		return
+	}
+
+	keyType := apiKeyType(config)
+	if config.APIKeyInQuery {
+		client.SetQueryParam(keyType.QueryParam(), config.APIKey)
+	} else {
+		client.SetHeader(keyType.Header(), config.APIKey)
+	}
+}
@@ -0,0 +1,300 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched by errors returned while the circuit breaker of an endpoint group is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned without sending the request while the circuit breaker of an endpoint group is open
+type CircuitOpenError struct {
+	// Group is the endpoint group, the first segment of the endpoint path, e.g. "coins"
+	Group string
+	// RetryAt is the time the circuit lets a probe request through
+	RetryAt time.Time
+}
+
+// Error implements the error interface
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open for %s until %s", e.Group, e.RetryAt.Format(time.RFC3339))
+}
+
+// Is makes errors.Is(err, ErrCircuitOpen) match
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every request until the cool-down has elapsed
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitBreakerPolicy defines when the circuit breaker of an endpoint group opens and closes.
+// Network errors and 5xx responses count as failures; 4xx responses and canceled requests do not.
+type CircuitBreakerPolicy struct {
+	// FailureRatio is the ratio of failed requests within Window that opens the circuit
+	FailureRatio float64
+	// MinRequests is the number of requests within Window below which the circuit stays closed
+	MinRequests int
+	// Window is the duration over which requests are counted
+	Window time.Duration
+	// CoolDown is how long the circuit stays open before letting probe requests through
+	CoolDown time.Duration
+	// HalfOpenRequests is the number of probe requests let through while half-open
+	HalfOpenRequests int
+	// ServeStale serves the last cached response, even if expired, while the circuit is open
+	ServeStale bool
+	// OnStateChange is called when the circuit of an endpoint group changes state
+	OnStateChange func(group string, from, to CircuitState)
+}
+
+// DefaultCircuitBreakerPolicy returns the default circuit breaker policy:
+// open when half of at least 10 requests within a minute fail, probe again after 30 seconds
+func DefaultCircuitBreakerPolicy() *CircuitBreakerPolicy {
+	return &CircuitBreakerPolicy{
+		FailureRatio:     0.5,
+		MinRequests:      10,
+		Window:           time.Minute,
+		CoolDown:         30 * time.Second,
+		HalfOpenRequests: 1,
+	}
+}
+
+// WithCircuitBreaker fails requests fast while an endpoint group keeps failing, according to policy
+func WithCircuitBreaker(policy *CircuitBreakerPolicy) ClientOption {
+	return func(c *Config) {
+		c.CircuitBreaker = policy
+	}
+}
+
+// circuit is the circuit breaker state of an endpoint group
+type circuit struct {
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+}
+
+// circuitBreaker keeps a circuit per endpoint group
+type circuitBreaker struct {
+	mu       sync.Mutex
+	policy   CircuitBreakerPolicy
+	circuits map[string]*circuit
+	now      func() time.Time
+}
+
+// newCircuitBreaker creates a circuit breaker from policy, nil when policy is nil
+func newCircuitBreaker(policy *CircuitBreakerPolicy) *circuitBreaker {
+	if policy == nil {
+		return nil
+	}
+
+	p := *policy
+	defaults := DefaultCircuitBreakerPolicy()
+	if p.FailureRatio <= 0 {
+		p.FailureRatio = defaults.FailureRatio
+	}
+	if p.MinRequests < 1 {
+		p.MinRequests = 1
+	}
+	if p.Window <= 0 {
+		p.Window = defaults.Window
+	}
+	if p.CoolDown <= 0 {
+		p.CoolDown = defaults.CoolDown
+	}
+	if p.HalfOpenRequests < 1 {
+		p.HalfOpenRequests = 1
+	}
+
+	return &circuitBreaker{policy: p, circuits: make(map[string]*circuit), now: time.Now}
+}
+
+// allow returns a *CircuitOpenError when the circuit of group rejects requests
+func (b *circuitBreaker) allow(group string) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	now := b.now()
+	c := b.circuit(group, now)
+	from := c.state
+
+	if c.state == CircuitOpen {
+		retryAt := c.openedAt.Add(b.policy.CoolDown)
+		if now.Before(retryAt) {
+			b.mu.Unlock()
+			return &CircuitOpenError{Group: group, RetryAt: retryAt}
+		}
+		c.state = CircuitHalfOpen
+		c.probes = 0
+	}
+	if c.state == CircuitHalfOpen {
+		if c.probes >= b.policy.HalfOpenRequests {
+			b.mu.Unlock()
+			return &CircuitOpenError{Group: group, RetryAt: now.Add(b.policy.CoolDown)}
+		}
+		c.probes++
+	}
+
+	to := c.state
+	b.mu.Unlock()
+
+	b.notify(group, from, to)
+	return nil
+}
+
+// record records the outcome of a request of group
+func (b *circuitBreaker) record(group string, err error) {
+	if b == nil {
+		return
+	}
+
+	failed := isCircuitFailure(err)
+
+	b.mu.Lock()
+	now := b.now()
+	c := b.circuit(group, now)
+	from := c.state
+
+	switch c.state {
+	case CircuitHalfOpen:
+		if failed {
+			c.open(now)
+		} else {
+			c.reset(now)
+		}
+	case CircuitClosed:
+		c.requests++
+		if failed {
+			c.failures++
+		}
+		if c.requests >= b.policy.MinRequests && float64(c.failures)/float64(c.requests) >= b.policy.FailureRatio {
+			c.open(now)
+		}
+	}
+
+	to := c.state
+	b.mu.Unlock()
+
+	b.notify(group, from, to)
+}
+
+// release gives back the probe slot of a half-open request that was not sent or was aborted
+func (b *circuitBreaker) release(group string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c, ok := b.circuits[group]; ok && c.state == CircuitHalfOpen && c.probes > 0 {
+		c.probes--
+	}
+}
+
+// state returns the state of the circuit of group
+func (b *circuitBreaker) state(group string) CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.circuit(group, b.now()).state
+}
+
+// circuit returns the circuit of group, starting a new counting window when the current one has elapsed.
+// b.mu must be held.
+func (b *circuitBreaker) circuit(group string, now time.Time) *circuit {
+	c, ok := b.circuits[group]
+	if !ok {
+		c = &circuit{windowStart: now}
+		b.circuits[group] = c
+	}
+	if c.state == CircuitClosed && now.Sub(c.windowStart) >= b.policy.Window {
+		c.reset(now)
+	}
+	return c
+}
+
+// notify calls the state change callback when the state changed
+func (b *circuitBreaker) notify(group string, from, to CircuitState) {
+	if from != to && b.policy.OnStateChange != nil {
+		b.policy.OnStateChange(group, from, to)
+	}
+}
+
+// open opens the circuit
+func (c *circuit) open(now time.Time) {
+	c.state = CircuitOpen
+	c.openedAt = now
+	c.probes = 0
+}
+
+// reset closes the circuit and starts a new counting window
+func (c *circuit) reset(now time.Time) {
+	c.state = CircuitClosed
+	c.windowStart = now
+	c.requests = 0
+	c.failures = 0
+	c.probes = 0
+}
+
+// isCircuitFailure reports whether err indicates that the API is unhealthy
+func isCircuitFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if apiErr, ok := AsAPIError(err); ok {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// endpointGroup returns the circuit breaker group of an endpoint path, its first segment
+func endpointGroup(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
+
+// CircuitState returns the state of the circuit breaker of an endpoint group, e.g. "coins".
+// Without circuit breaker every group is closed.
+func (c *BaseClient) CircuitState(group string) CircuitState {
+	return c.breaker.state(group)
+}
@@ -0,0 +1,364 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var (
+	errServer  = &APIError{StatusCode: http.StatusInternalServerError}
+	errClient  = &APIError{StatusCode: http.StatusNotFound}
+	errNetwork = errors.New("connection reset by peer")
+)
+
+// breakerStep is an event applied to a circuit breaker
+type breakerStep struct {
+	// advance moves the clock forward before the event
+	advance time.Duration
+	// group is the endpoint group of the event, "coins" when empty
+	group string
+	// rejected expects the request to be rejected, otherwise its outcome err is recorded
+	rejected bool
+	// err is the outcome of the request
+	err error
+	// release gives back the probe slot of the request instead of recording its outcome
+	release bool
+	// want is the state of the group after the event
+	want CircuitState
+}
+
+// testPolicy opens when half of at least 4 requests within a minute fail, probing after 30 seconds
+func testPolicy() CircuitBreakerPolicy {
+	return CircuitBreakerPolicy{
+		FailureRatio:     0.5,
+		MinRequests:      4,
+		Window:           time.Minute,
+		CoolDown:         30 * time.Second,
+		HalfOpenRequests: 1,
+	}
+}
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	tests := []struct {
+		name            string
+		policy          func(p *CircuitBreakerPolicy)
+		steps           []breakerStep
+		wantTransitions []string
+	}{
+		{
+			name: "trips on failure ratio",
+			steps: []breakerStep{
+				{err: nil, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{err: nil, want: CircuitClosed},
+				{err: errNetwork, want: CircuitOpen},
+				{rejected: true, want: CircuitOpen},
+			},
+			wantTransitions: []string{"coins: closed -> open"},
+		},
+		{
+			name: "stays closed below the minimum number of requests",
+			steps: []breakerStep{
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+			},
+		},
+		{
+			name: "stays closed below the failure ratio",
+			steps: []breakerStep{
+				{err: errServer, want: CircuitClosed},
+				{err: nil, want: CircuitClosed},
+				{err: nil, want: CircuitClosed},
+				{err: nil, want: CircuitClosed},
+				{err: nil, want: CircuitClosed},
+			},
+		},
+		{
+			name: "client errors are not failures",
+			steps: []breakerStep{
+				{err: errClient, want: CircuitClosed},
+				{err: errClient, want: CircuitClosed},
+				{err: errClient, want: CircuitClosed},
+				{err: errClient, want: CircuitClosed},
+			},
+		},
+		{
+			name: "failures of an elapsed window are forgotten",
+			steps: []breakerStep{
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{advance: time.Minute, err: errServer, want: CircuitClosed},
+				{err: nil, want: CircuitClosed},
+				{err: nil, want: CircuitClosed},
+				{err: nil, want: CircuitClosed},
+			},
+		},
+		{
+			name: "groups are independent",
+			steps: []breakerStep{
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitOpen},
+				{group: "exchanges", err: nil, want: CircuitClosed},
+				{rejected: true, want: CircuitOpen},
+			},
+			wantTransitions: []string{"coins: closed -> open"},
+		},
+		{
+			name: "rejects until the cool-down has elapsed",
+			steps: []breakerStep{
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitOpen},
+				{advance: 29 * time.Second, rejected: true, want: CircuitOpen},
+				{advance: time.Second, err: nil, want: CircuitClosed},
+			},
+			wantTransitions: []string{"coins: closed -> open", "coins: open -> half-open", "coins: half-open -> closed"},
+		},
+		{
+			name: "successful half-open probe closes the circuit",
+			steps: []breakerStep{
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitOpen},
+				{advance: 30 * time.Second, err: nil, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+			},
+			wantTransitions: []string{"coins: closed -> open", "coins: open -> half-open", "coins: half-open -> closed"},
+		},
+		{
+			name: "failed half-open probe reopens the circuit",
+			steps: []breakerStep{
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitClosed},
+				{err: errServer, want: CircuitOpen},
+				{advance: 30 * time.Second, err: errServer, want: CircuitOpen},
+				{advance: 29 * time.Second, rejected: true, want: CircuitOpen},
+				{advance: time.Second, err: nil, want: CircuitClosed},
+			},
+			wantTransitions: []string{
+				"coins: closed -> open",
+				"coins: open -> half-open",
+				"coins: half-open -> open",
+				"coins: open -> half-open",
+				"coins: half-open -> closed",
+			},
+		},
+		{
+			name: "released probe slots are reused",
+			policy: func(p *CircuitBreakerPolicy) {
+				p.MinRequests = 1
+				p.HalfOpenRequests = 2
+			},
+			steps: []breakerStep{
+				{err: errServer, want: CircuitOpen},
+				{advance: 30 * time.Second, release: true, want: CircuitHalfOpen},
+				{release: true, want: CircuitHalfOpen},
+				{release: true, want: CircuitHalfOpen},
+				{err: nil, want: CircuitClosed},
+			},
+			wantTransitions: []string{"coins: closed -> open", "coins: open -> half-open", "coins: half-open -> closed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			policy := testPolicy()
+			if tt.policy != nil {
+				tt.policy(&policy)
+			}
+			var transitions []string
+			policy.OnStateChange = func(group string, from, to CircuitState) {
+				transitions = append(transitions, fmt.Sprintf("%s: %s -> %s", group, from, to))
+			}
+			breaker := newCircuitBreaker(&policy)
+			breaker.now = clock.Now
+
+			for i, step := range tt.steps {
+				clock.Advance(step.advance)
+				group := step.group
+				if group == "" {
+					group = "coins"
+				}
+
+				err := breaker.allow(group)
+				switch {
+				case step.rejected:
+					var openErr *CircuitOpenError
+					if !errors.As(err, &openErr) || !errors.Is(err, ErrCircuitOpen) || openErr.Group != group {
+						t.Fatalf("step %d: allow error = %v, want a CircuitOpenError of %s", i, err, group)
+					}
+				case err != nil:
+					t.Fatalf("step %d: allow error = %v, want nil", i, err)
+				case step.release:
+					breaker.release(group)
+				default:
+					breaker.record(group, step.err)
+				}
+
+				if got := breaker.state(group); got != step.want {
+					t.Fatalf("step %d: state = %s, want %s", i, got, step.want)
+				}
+			}
+
+			if fmt.Sprint(transitions) != fmt.Sprint(tt.wantTransitions) {
+				t.Errorf("transitions = %q, want %q", transitions, tt.wantTransitions)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerHalfOpenRejectsExtraProbes(t *testing.T) {
+	clock := newFakeClock()
+	policy := testPolicy()
+	policy.MinRequests = 1
+	breaker := newCircuitBreaker(&policy)
+	breaker.now = clock.Now
+
+	_ = breaker.allow("coins")
+	breaker.record("coins", errServer)
+	clock.Advance(policy.CoolDown)
+
+	if err := breaker.allow("coins"); err != nil {
+		t.Fatalf("probe rejected: %v", err)
+	}
+	err := breaker.allow("coins")
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) {
+		t.Fatalf("second probe error = %v, want a CircuitOpenError", err)
+	}
+	if want := clock.Now().Add(policy.CoolDown); !openErr.RetryAt.Equal(want) {
+		t.Errorf("RetryAt = %s, want %s", openErr.RetryAt, want)
+	}
+}
+
+func TestCircuitOpenErrorRetryAt(t *testing.T) {
+	clock := newFakeClock()
+	policy := testPolicy()
+	policy.MinRequests = 1
+	breaker := newCircuitBreaker(&policy)
+	breaker.now = clock.Now
+
+	_ = breaker.allow("coins")
+	breaker.record("coins", errServer)
+	openedAt := clock.Now()
+	clock.Advance(10 * time.Second)
+
+	var openErr *CircuitOpenError
+	if err := breaker.allow("coins"); !errors.As(err, &openErr) {
+		t.Fatalf("allow error = %v, want a CircuitOpenError", err)
+	}
+	if want := openedAt.Add(policy.CoolDown); !openErr.RetryAt.Equal(want) {
+		t.Errorf("RetryAt = %s, want %s", openErr.RetryAt, want)
+	}
+}
+
+func TestNilCircuitBreaker(t *testing.T) {
+	var breaker *circuitBreaker
+	if err := breaker.allow("coins"); err != nil {
+		t.Errorf("allow error = %v, want nil", err)
+	}
+	breaker.record("coins", errServer)
+	breaker.release("coins")
+	if got := breaker.state("coins"); got != CircuitClosed {
+		t.Errorf("state = %s, want closed", got)
+	}
+}
+
+func TestCircuitOpenFallback(t *testing.T) {
+	const cached = `{"gecko_says":"(V3) To the Moon!"}`
+
+	tests := []struct {
+		name       string
+		serveStale bool
+		cacheEntry bool
+		wantErr    bool
+	}{
+		{name: "without fallback", serveStale: false, cacheEntry: true, wantErr: true},
+		{name: "fallback without cached response", serveStale: true, cacheEntry: false, wantErr: true},
+		{name: "fallback to the last cached response", serveStale: true, cacheEntry: true, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			defer server.Close()
+
+			clock := newFakeClock()
+			policy := testPolicy()
+			policy.MinRequests = 1
+			policy.ServeStale = tt.serveStale
+			cache := NewMemoryCache(10)
+			client := NewBaseClient(DefaultConfig(),
+				WithBaseURL(server.URL),
+				WithRetryPolicy(NoRetryPolicy()),
+				WithCircuitBreaker(&policy),
+				WithCache(cache),
+				WithCacheTTL("/ping", time.Minute),
+			)
+			client.breaker.now = clock.Now
+
+			// The failing request opens the circuit
+			if apiErr, ok := AsAPIError(client.Get("/ping", nil, nil)); !ok || apiErr.StatusCode != http.StatusInternalServerError {
+				t.Fatalf("first request error = %v, want a server error", apiErr)
+			}
+			if got := client.CircuitState("ping"); got != CircuitOpen {
+				t.Fatalf("state = %s, want open", got)
+			}
+
+			if tt.cacheEntry {
+				expired := time.Now().Add(-time.Hour)
+				cache.Set(client.cacheKey(&Call{Method: http.MethodGet, Path: "/ping"}), &CacheEntry{
+					Body:      []byte(cached),
+					StoredAt:  expired.Add(-time.Minute),
+					ExpiresAt: expired,
+				})
+			}
+
+			var result map[string]string
+			var meta ResponseMeta
+			err := client.GetWithContext(WithResponseMeta(context.Background(), &meta), "/ping", nil, &result)
+
+			if got := calls.Load(); got != 1 {
+				t.Errorf("server received %d requests, want 1", got)
+			}
+			if tt.wantErr {
+				var openErr *CircuitOpenError
+				if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &openErr) {
+					t.Fatalf("error = %v, want ErrCircuitOpen", err)
+				}
+				if openErr.Group != "ping" || !openErr.RetryAt.Equal(clock.Now().Add(policy.CoolDown)) {
+					t.Errorf("error = %+v, want group ping retrying at %s", openErr, clock.Now().Add(policy.CoolDown))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("error = %v, want the cached response", err)
+			}
+			if result["gecko_says"] != "(V3) To the Moon!" {
+				t.Errorf("result = %v", result)
+			}
+			if !meta.FromCache || !meta.Stale {
+				t.Errorf("meta = %+v, want a stale cached response", meta)
+			}
+		})
+	}
+}
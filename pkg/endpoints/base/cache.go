@@ -0,0 +1,160 @@
+package base
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached response body
+type CacheEntry struct {
+	// Body is the raw response body
+	Body []byte `json:"body"`
+	// StoredAt is the time the entry was stored
+	StoredAt time.Time `json:"stored_at"`
+	// ExpiresAt is the time the entry stops being fresh
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Fresh reports whether the entry has not expired at now
+func (e *CacheEntry) Fresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// Cache stores response bodies keyed by request.
+// Implementations must be safe for concurrent use and may keep expired entries.
+type Cache interface {
+	// Get returns the entry stored under key, fresh or not
+	Get(key string) (*CacheEntry, bool)
+	// Set stores entry under key
+	Set(key string, entry *CacheEntry)
+	// Delete removes the entry stored under key
+	Delete(key string)
+}
+
+// CacheMode controls how a single request uses the cache
+type CacheMode int
+
+const (
+	// CacheDefault serves fresh cached responses and stores new ones
+	CacheDefault CacheMode = iota
+	// CacheBypass neither reads nor writes the cache
+	CacheBypass
+	// CacheRefresh skips the cached response and stores the new one
+	CacheRefresh
+)
+
+type cacheModeKey struct{}
+
+// WithCacheMode returns a context that makes requests use the cache according to mode
+func WithCacheMode(ctx context.Context, mode CacheMode) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, mode)
+}
+
+// WithCacheBypass returns a context whose requests neither read nor write the cache
+func WithCacheBypass(ctx context.Context) context.Context {
+	return WithCacheMode(ctx, CacheBypass)
+}
+
+// WithCacheRefresh returns a context whose requests skip cached responses and store new ones
+func WithCacheRefresh(ctx context.Context) context.Context {
+	return WithCacheMode(ctx, CacheRefresh)
+}
+
+func cacheModeFromContext(ctx context.Context) CacheMode {
+	if mode, ok := ctx.Value(cacheModeKey{}).(CacheMode); ok {
+		return mode
+	}
+	return CacheDefault
+}
+
+var (
+	cacheTTLsMu sync.RWMutex
+	cacheTTLs   = map[string]time.Duration{}
+)
+
+// RegisterCacheTTL sets the default cache TTL of an endpoint path template.
+// Endpoint packages register the TTLs of their endpoints on init.
+func RegisterCacheTTL(path string, ttl time.Duration) {
+	cacheTTLsMu.Lock()
+	defer cacheTTLsMu.Unlock()
+
+	cacheTTLs[path] = ttl
+}
+
+// DefaultCacheTTL returns the default cache TTL of an endpoint path template, 0 if it is not cached
+func DefaultCacheTTL(path string) time.Duration {
+	cacheTTLsMu.RLock()
+	defer cacheTTLsMu.RUnlock()
+
+	return cacheTTLs[path]
+}
+
+// cacheTTL returns the TTL of path, preferring the configured overrides
+func (c *BaseClient) cacheTTL(path string) time.Duration {
+	if ttl, ok := c.config.CacheTTLs[path]; ok {
+		return ttl
+	}
+	return DefaultCacheTTL(path)
+}
+
+// CacheKey returns the cache key of a request: the method, the path with its
+// parameters substituted and the query parameters sorted by name.
+// Clients prefix it with their scope, see cacheScope.
+func CacheKey(method, path string, pathParams, queryParams map[string]string) string {
+	for name, value := range pathParams {
+		path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.ToUpper(method))
+	b.WriteByte(' ')
+	b.WriteString(path)
+
+	if len(queryParams) > 0 {
+		values := url.Values{}
+		for name, value := range queryParams {
+			values.Set(name, value)
+		}
+		b.WriteByte('?')
+		b.WriteString(values.Encode())
+	}
+
+	return b.String()
+}
+
+// cacheScope returns the part of the cache keys of a client identifying who is asking:
+// the resolved base URL and a fingerprint of the API key or key pool, so that clients
+// of different hosts or keys sharing a cache never serve each other's responses
+func cacheScope(config *Config) string {
+	var keys []string
+	switch {
+	case config.APIKeyPool != nil:
+		keys = config.APIKeyPool.Keys()
+		sort.Strings(keys)
+	case config.APIKey != "":
+		keys = []string{config.APIKey}
+	}
+
+	scope := resolveBaseURL(config)
+	if len(keys) == 0 {
+		return scope
+	}
+
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+	}
+	return scope + " " + string(apiKeyType(config)) + ":" + hex.EncodeToString(hash.Sum(nil)[:8])
+}
+
+// cacheKey returns the key of call in the cache of the client
+func (c *BaseClient) cacheKey(call *Call) string {
+	return c.cacheScope + " " + CacheKey(call.Method, call.Path, call.PathParams, call.QueryParams)
+}
@@ -0,0 +1,290 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingServer answers every request with the number of requests received so far
+type countingServer struct {
+	*httptest.Server
+	calls atomic.Int32
+}
+
+func newCountingServer(t *testing.T) *countingServer {
+	t.Helper()
+
+	s := &countingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := s.calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"n":%d}`, n)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newCachingClient returns a client of server caching /ping for a minute in cache, driven by clock
+func newCachingClient(server *countingServer, cache Cache, clock *fakeClock, options ...ClientOption) *BaseClient {
+	options = append([]ClientOption{
+		WithBaseURL(server.URL),
+		WithRetryPolicy(NoRetryPolicy()),
+		WithCache(cache),
+		WithCacheTTL("/ping", time.Minute),
+	}, options...)
+	client := NewBaseClient(DefaultConfig(), options...)
+	client.now = clock.Now
+	return client
+}
+
+// ping returns the request number answered by the counting server
+func ping(t *testing.T, ctx context.Context, client *BaseClient) int {
+	t.Helper()
+
+	var result struct {
+		N int `json:"n"`
+	}
+	if err := client.GetWithContext(ctx, "/ping", nil, &result); err != nil {
+		t.Fatal(err)
+	}
+	return result.N
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2)
+	entry := func(body string) *CacheEntry { return &CacheEntry{Body: []byte(body)} }
+
+	cache.Set("a", entry("a"))
+	cache.Set("b", entry("b"))
+	cache.Get("a")
+	cache.Set("c", entry("c"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("least recently used entry b kept")
+	}
+	for _, key := range []string{"a", "c"} {
+		if got, ok := cache.Get(key); !ok || string(got.Body) != key {
+			t.Errorf("Get(%s) = %v, %v", key, got, ok)
+		}
+	}
+
+	// Replacing an entry refreshes it without growing the cache
+	cache.Set("a", entry("a2"))
+	cache.Set("d", entry("d"))
+	if got, ok := cache.Get("a"); !ok || string(got.Body) != "a2" {
+		t.Errorf("Get(a) = %v, %v, want the replaced entry", got, ok)
+	}
+	if _, ok := cache.Get("c"); ok {
+		t.Error("least recently used entry c kept")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+
+	cache.Delete("a")
+	if _, ok := cache.Get("a"); ok || cache.Len() != 1 {
+		t.Errorf("entry a kept after Delete, Len() = %d", cache.Len())
+	}
+}
+
+func TestMemoryCacheUnbounded(t *testing.T) {
+	cache := NewMemoryCache(0)
+	for i := 0; i < 100; i++ {
+		cache.Set(fmt.Sprint(i), &CacheEntry{})
+	}
+	if cache.Len() != 100 {
+		t.Errorf("Len() = %d, want 100", cache.Len())
+	}
+}
+
+func TestFileCachePersistence(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	stored := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cache, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Set("GET /ping", &CacheEntry{Body: []byte(`{"n":1}`), StoredAt: stored, ExpiresAt: stored.Add(time.Minute)})
+
+	// A new cache on the same directory reads the entry back
+	reopened, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := reopened.Get("GET /ping")
+	if !ok {
+		t.Fatal("entry lost")
+	}
+	if string(entry.Body) != `{"n":1}` || !entry.StoredAt.Equal(stored) || !entry.ExpiresAt.Equal(stored.Add(time.Minute)) {
+		t.Errorf("entry = %+v", entry)
+	}
+	if !entry.Fresh(stored.Add(time.Minute-time.Second)) || entry.Fresh(stored.Add(time.Minute)) {
+		t.Error("entry fresh outside of its TTL")
+	}
+
+	reopened.Delete("GET /ping")
+	if _, ok := cache.Get("GET /ping"); ok {
+		t.Error("entry kept after Delete")
+	}
+
+	// Corrupt entries are misses
+	if err := os.WriteFile(cache.path("corrupt"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("corrupt"); ok {
+		t.Error("corrupt entry returned")
+	}
+}
+
+func TestClientCacheExpiry(t *testing.T) {
+	fileCache, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		cache Cache
+	}{
+		{name: "memory", cache: NewMemoryCache(10)},
+		{name: "file", cache: fileCache},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCountingServer(t)
+			clock := newFakeClock()
+			client := newCachingClient(server, tt.cache, clock)
+			ctx := context.Background()
+
+			if got := ping(t, ctx, client); got != 1 {
+				t.Fatalf("first response %d, want 1", got)
+			}
+			clock.Advance(time.Minute - time.Second)
+			if got := ping(t, ctx, client); got != 1 {
+				t.Errorf("response within the TTL %d, want the cached 1", got)
+			}
+
+			// A client sharing the cache is served the same entry
+			other := newCachingClient(server, tt.cache, clock)
+			if got := ping(t, ctx, other); got != 1 {
+				t.Errorf("response of another client %d, want the cached 1", got)
+			}
+
+			clock.Advance(time.Second)
+			if got := ping(t, ctx, client); got != 2 {
+				t.Errorf("response after the TTL %d, want 2", got)
+			}
+			if got := ping(t, ctx, client); got != 2 {
+				t.Errorf("response after the refresh %d, want the cached 2", got)
+			}
+		})
+	}
+}
+
+func TestClientCacheModes(t *testing.T) {
+	server := newCountingServer(t)
+	client := newCachingClient(server, NewMemoryCache(10), newFakeClock())
+	ctx := context.Background()
+
+	if got := ping(t, ctx, client); got != 1 {
+		t.Fatalf("first response %d, want 1", got)
+	}
+
+	// Bypass neither reads nor writes the cache
+	if got := ping(t, WithCacheBypass(ctx), client); got != 2 {
+		t.Errorf("bypassed response %d, want 2", got)
+	}
+	if got := ping(t, ctx, client); got != 1 {
+		t.Errorf("response after a bypass %d, want the cached 1", got)
+	}
+
+	// Refresh skips the cached response and stores the new one
+	if got := ping(t, WithCacheRefresh(ctx), client); got != 3 {
+		t.Errorf("refreshed response %d, want 3", got)
+	}
+	if got := ping(t, ctx, client); got != 3 {
+		t.Errorf("response after a refresh %d, want the cached 3", got)
+	}
+	if got := ping(t, WithCacheMode(ctx, CacheDefault), client); got != 3 {
+		t.Errorf("response in the default mode %d, want the cached 3", got)
+	}
+	if got := server.calls.Load(); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+}
+
+func TestClientCacheScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     []ClientOption
+		second    []ClientOption
+		otherHost bool
+		shared    bool
+	}{
+		{
+			name:   "same key",
+			first:  []ClientOption{WithProAPIKey("key-a")},
+			second: []ClientOption{WithProAPIKey("key-a")},
+			shared: true,
+		},
+		{
+			name:   "different keys",
+			first:  []ClientOption{WithProAPIKey("key-a")},
+			second: []ClientOption{WithProAPIKey("key-b")},
+		},
+		{
+			name:   "demo and pro plans",
+			first:  []ClientOption{WithDemoAPIKey("key-a")},
+			second: []ClientOption{WithProAPIKey("key-a")},
+		},
+		{
+			name:   "key and no key",
+			first:  []ClientOption{WithProAPIKey("key-a")},
+			second: nil,
+		},
+		{
+			name:   "same key pool",
+			first:  []ClientOption{WithAPIKeyPool(NewAPIKeyPool("key-a", "key-b"), APIKeyTypePro)},
+			second: []ClientOption{WithAPIKeyPool(NewAPIKeyPool("key-b", "key-a"), APIKeyTypePro)},
+			shared: true,
+		},
+		{
+			name:      "different hosts",
+			first:     []ClientOption{WithProAPIKey("key-a")},
+			second:    []ClientOption{WithProAPIKey("key-a")},
+			otherHost: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewMemoryCache(10)
+			clock := newFakeClock()
+			server := newCountingServer(t)
+			secondServer := server
+			if tt.otherHost {
+				secondServer = newCountingServer(t)
+			}
+
+			ping(t, context.Background(), newCachingClient(server, cache, clock, tt.first...))
+			got := ping(t, context.Background(), newCachingClient(secondServer, cache, clock, tt.second...))
+
+			calls := server.calls.Load()
+			if tt.otherHost {
+				calls += secondServer.calls.Load()
+			}
+			if shared := got == 1 && calls == 1; shared != tt.shared {
+				t.Errorf("cache shared = %v, want %v", shared, tt.shared)
+			}
+		})
+	}
+}
@@ -1,33 +1,76 @@
 package base
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"resty.dev/v3"
 )
 
-// ClientOption defines client configuration options
-type ClientOption func(*resty.Client)
-
 // Config defines the base client configuration
 type Config struct {
 	// BaseURL is the base URL for the API
 	BaseURL string
 	// Timeout is the request timeout duration
 	Timeout time.Duration
-	// RetryCount is the number of retry attempts
+	// HTTPClient is the HTTP client used to send requests, nil uses a new default client
+	HTTPClient *http.Client
+	// Transport is the HTTP transport used to send requests, overriding the transport of HTTPClient
+	Transport http.RoundTripper
+	// UserAgent is the User-Agent header sent with every request
+	UserAgent string
+	// ProxyURL is the URL of the proxy requests are sent through
+	ProxyURL string
+	// RetryCount is the number of retry attempts, used when RetryPolicy is nil
 	RetryCount int
-	// RetryWaitTime is the duration to wait between retries
+	// RetryWaitTime is the duration to wait before the first retry, used when RetryPolicy is nil
 	RetryWaitTime time.Duration
+	// RetryPolicy defines when and how failed requests are retried
+	RetryPolicy *RetryPolicy
 	// APIKey is the API key for authentication
 	APIKey string
+	// APIKeyType is the plan type of APIKey, selecting the header and host; defaults to APIKeyTypePro
+	APIKeyType APIKeyType
+	// APIKeyInQuery sends APIKey as a query parameter instead of a header
+	APIKeyInQuery bool
+	// APIKeyPool spreads requests over several keys of type APIKeyType, used instead of APIKey
+	APIKeyPool *APIKeyPool
+	// RateLimit is the maximum number of requests per minute, 0 disables client-side rate limiting
+	RateLimit int
+	// RateLimitBurst is the number of requests that may be sent at once before limiting applies
+	RateLimitBurst int
+	// RateLimitPolicy defines whether rate limited requests wait or fail fast
+	RateLimitPolicy RateLimitPolicy
+	// CoalesceRequests makes concurrent identical GET requests share a single upstream call
+	CoalesceRequests bool
+	// CircuitBreaker fails requests fast while an endpoint group keeps failing, nil disables it
+	CircuitBreaker *CircuitBreakerPolicy
+	// Cache stores GET responses, nil disables caching
+	Cache Cache
+	// CacheTTLs overrides the default cache TTL per endpoint path template, a zero TTL disables caching
+	CacheTTLs map[string]time.Duration
+	// Middlewares wrap every call, the first middleware being the outermost
+	Middlewares []Middleware
+	// TracerProvider creates an OpenTelemetry span for every call, nil disables tracing
+	TracerProvider trace.TracerProvider
+	// Metrics receives measurements of every request, nil discards them
+	Metrics Metrics
+	// Logger logs every request with API keys redacted, nil disables logging
+	Logger *slog.Logger
+	// LogLevels defines the level of each kind of log record, nil uses DefaultLogLevels
+	LogLevels *LogLevels
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	return &Config{
-		BaseURL:       "https://api.coingecko.com/api/v3",
+		BaseURL:       PublicBaseURL,
 		Timeout:       10 * time.Second,
 		RetryCount:    3,
 		RetryWaitTime: 1 * time.Second,
@@ -38,40 +81,97 @@ func DefaultConfig() *Config {
 type BaseClient struct {
 	httpClient *resty.Client
 	config     *Config
+	limiter    *RateLimiter
+	retry      *RetryPolicy
+	roundTrip  RoundTrip
+	metrics    Metrics
+	logger     *requestLogger
+	flights    flightGroup
+	breaker    *circuitBreaker
+	cacheScope string
+	now        func() time.Time
 }
 
-// NewBaseClient creates a new base client
+// NewBaseClient creates a new base client from config, adjusted by options.
+// config itself is not modified.
 func NewBaseClient(config *Config, options ...ClientOption) *BaseClient {
 	if config == nil {
 		config = DefaultConfig()
 	}
 
-	client := resty.New()
-	client.SetBaseURL(config.BaseURL)
-	client.SetTimeout(config.Timeout)
-
-	// Configure retries
-	client.SetRetryCount(config.RetryCount)
-	client.SetRetryWaitTime(config.RetryWaitTime)
-
-	// Set API key
-	if config.APIKey != "" {
-		client.SetHeader("x-cg-pro-api-key", config.APIKey)
+	// Apply options to a copy so that a shared config stays untouched
+	cfg := *config
+	config = &cfg
+	for _, option := range options {
+		option(config)
 	}
 
-	// Apply custom options
-	for _, option := range options {
-		option(client)
+	var client *resty.Client
+	if config.HTTPClient != nil {
+		client = resty.NewWithClient(config.HTTPClient)
+	} else {
+		client = resty.New()
 	}
+	if config.Transport != nil {
+		client.SetTransport(config.Transport)
+	}
+	if config.ProxyURL != "" {
+		client.SetProxy(config.ProxyURL)
+	}
+	if config.UserAgent != "" {
+		client.SetHeader("User-Agent", config.UserAgent)
+	}
+
+	client.SetBaseURL(resolveBaseURL(config))
+	client.SetTimeout(config.Timeout)
+
+	// Set API key
+	applyAPIKey(client, config)
 
-	return &BaseClient{
+	baseClient := &BaseClient{
 		httpClient: client,
 		config:     config,
+		limiter:    NewRateLimiter(config.RateLimit, config.RateLimitBurst, config.RateLimitPolicy),
+		retry:      retryPolicyFromConfig(config),
+		metrics:    config.Metrics,
+		logger:     newRequestLogger(config),
+		breaker:    newCircuitBreaker(config.CircuitBreaker),
+		cacheScope: cacheScope(config),
+		now:        time.Now,
 	}
+	if baseClient.metrics == nil {
+		baseClient.metrics = NopMetrics{}
+	}
+	middlewares := config.Middlewares
+	if config.TracerProvider != nil {
+		// Tracing is outermost so that spans cover cache lookups, retries and custom middlewares
+		tracing := tracingMiddleware(config.TracerProvider.Tracer(tracerName))
+		middlewares = append([]Middleware{tracing}, middlewares...)
+	}
+	baseClient.roundTrip = chainMiddlewares(baseClient.transport, middlewares)
+
+	return baseClient
+}
+
+// RateLimiter returns the rate limiter shared by every request of the client
+func (c *BaseClient) RateLimiter() *RateLimiter {
+	return c.limiter
+}
+
+// APIKeyPool returns the configured API key pool, nil when the client uses a single key
+func (c *BaseClient) APIKeyPool() *APIKeyPool {
+	return c.config.APIKeyPool
+}
+
+// SetRateLimit changes the maximum number of requests per minute, 0 disables client-side rate limiting
+func (c *BaseClient) SetRateLimit(requestsPerMinute int) {
+	c.limiter.SetRate(requestsPerMinute, c.config.RateLimitBurst)
 }
 
 // RequestOptions defines request options
 type RequestOptions struct {
+	// Operation is the logical name of the endpoint method, e.g. "coins.GetCoinsList"
+	Operation string
 	// PathParams are path parameters
 	PathParams map[string]string
 	// QueryParams are query parameters
@@ -84,67 +184,345 @@ type RequestOptions struct {
 
 // DoRequest executes an HTTP request
 func (c *BaseClient) DoRequest(method, path string, opts *RequestOptions, result interface{}) error {
+	return c.DoRequestWithContext(context.Background(), method, path, opts, result)
+}
+
+// DoRequestWithContext executes an HTTP request bound to ctx.
+// Cancellation and deadlines of ctx apply to the request and to all of its retries.
+func (c *BaseClient) DoRequestWithContext(ctx context.Context, method, path string, opts *RequestOptions, result interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	if opts == nil {
 		opts = &RequestOptions{}
 	}
 
-	req := c.httpClient.R()
+	call := &Call{
+		Operation:   opts.Operation,
+		Method:      method,
+		Path:        path,
+		PathParams:  opts.PathParams,
+		QueryParams: opts.QueryParams,
+		Headers:     opts.Headers,
+		Body:        opts.Body,
+		Result:      result,
+	}
+
+	meta := responseMetaFromContext(ctx)
+	stats := &callStats{}
+	if meta != nil {
+		ctx = withCallStats(ctx, stats)
+	}
+
+	start := time.Now()
+	response, err := c.roundTrip(ctx, call)
+	if meta != nil {
+		fillResponseMeta(meta, call, response, time.Since(start), stats, aggregatesResponseMeta(ctx), err)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Responses produced by a short-circuiting middleware are decoded here
+	if response != nil && !response.decoded {
+		return decodeResult(response.Body, result)
+	}
+
+	return nil
+}
+
+// transport is the innermost RoundTrip of the middleware chain
+func (c *BaseClient) transport(ctx context.Context, call *Call) (*Response, error) {
+	response, err := c.fetch(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decodeResult(response.Body, call.Result); err != nil {
+		return nil, err
+	}
+	response.Result = call.Result
+	response.decoded = true
+
+	return response, nil
+}
+
+// fetch returns the response of a call, served from the cache when possible
+func (c *BaseClient) fetch(ctx context.Context, call *Call) (*Response, error) {
+	mode := cacheModeFromContext(ctx)
+	ttl := c.cacheTTL(call.Path)
+	if c.config.Cache == nil || call.Method != http.MethodGet || ttl <= 0 || mode == CacheBypass {
+		return c.executeShared(ctx, call)
+	}
+
+	key := c.cacheKey(call)
+	if mode == CacheDefault {
+		if entry, ok := c.config.Cache.Get(key); ok && entry.Fresh(c.now()) {
+			c.metrics.IncCacheHit(call.Path)
+			return &Response{StatusCode: http.StatusOK, Body: entry.Body, FromCache: true}, nil
+		}
+	}
+	c.metrics.IncCacheMiss(call.Path)
+
+	response, err := c.executeShared(ctx, call)
+	if err != nil {
+		if c.breaker != nil && c.breaker.policy.ServeStale && errors.Is(err, ErrCircuitOpen) {
+			if entry, ok := c.config.Cache.Get(key); ok {
+				return &Response{StatusCode: http.StatusOK, Body: entry.Body, FromCache: true, Stale: !entry.Fresh(c.now())}, nil
+			}
+		}
+		return nil, err
+	}
+
+	now := c.now()
+	c.config.Cache.Set(key, &CacheEntry{
+		Body:      response.Body,
+		StoredAt:  now,
+		ExpiresAt: now.Add(ttl),
+	})
+
+	return response, nil
+}
+
+// executeWithRetry executes a call, retrying failed attempts according to the retry policy
+func (c *BaseClient) executeWithRetry(ctx context.Context, call *Call) (*Response, error) {
+	stats := callStatsFromContext(ctx)
+	credits := 0
+	for attempt := 1; ; attempt++ {
+		response, err := c.execute(ctx, call)
+		stats.count(response, err)
+		if err == nil {
+			response.Attempts = attempt
+			response.Credits = credits + creditCost(response.StatusCode)
+			return response, nil
+		}
+		if apiErr, ok := AsAPIError(err); ok {
+			credits += creditCost(apiErr.StatusCode)
+		}
+
+		wait, retry := c.retry.nextWait(attempt, call.Method, err)
+		if c.failover(ctx, attempt, call.Method, err) {
+			// Another key of the pool can be used right away
+			wait, retry = 0, true
+		}
+		if !retry {
+			return nil, err
+		}
+
+		retryAttempt := RetryAttempt{
+			Attempt:  attempt,
+			Method:   call.Method,
+			Endpoint: call.Path,
+			Err:      err,
+			Wait:     wait,
+		}
+		if apiErr, ok := AsAPIError(err); ok {
+			retryAttempt.StatusCode = apiErr.StatusCode
+		}
+		addRetryEvent(ctx, retryAttempt)
+		c.metrics.IncRetry(call.Path)
+		c.logger.logRetry(ctx, call, retryAttempt)
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(retryAttempt)
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// execute performs a single attempt of a call
+func (c *BaseClient) execute(ctx context.Context, call *Call) (*Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapContextError(err)
+	}
+
+	group := endpointGroup(call.Path)
+	if err := c.breaker.allow(group); err != nil {
+		return nil, err
+	}
+
+	// Wait for the rate limiter, every attempt consumes a token
+	if err := c.limiter.Wait(ctx); err != nil {
+		c.breaker.release(group)
+		if errors.Is(err, ErrRateLimited) {
+			c.metrics.IncRateLimited(call.Path, RateLimitSourceClient)
+			c.logger.logRateLimited(ctx, call)
+		}
+		return nil, err
+	}
+
+	apiKey, pooled, err := c.requestAPIKey(ctx)
+	if err != nil {
+		c.breaker.release(group)
+		return nil, err
+	}
+
+	start := time.Now()
+	response, err := c.send(ctx, call, apiKey)
+	if pooled {
+		c.config.APIKeyPool.report(apiKey, err)
+	}
+	if ctx.Err() != nil {
+		// Requests aborted by the caller say nothing about the health of the API
+		c.breaker.release(group)
+	} else {
+		c.breaker.record(group, err)
+	}
+	duration := time.Since(start)
+	recordAttempt(c.metrics, call.Path, response, duration, err)
+	c.logger.logAttempt(ctx, call, response, duration, err)
+
+	return response, err
+}
+
+// requestAPIKey returns the key a request is authenticated with when it differs from the
+// client-level key, and whether the key was acquired from the key pool
+func (c *BaseClient) requestAPIKey(ctx context.Context) (string, bool, error) {
+	if apiKey, ok := apiKeyFromContext(ctx); ok {
+		return apiKey, false, nil
+	}
+	if c.config.APIKeyPool == nil {
+		return "", false, nil
+	}
+
+	apiKey, err := c.config.APIKeyPool.acquire()
+	if err != nil {
+		return "", false, err
+	}
+	return apiKey, true, nil
+}
+
+// failover reports whether a call failed because of its pooled key and can be retried at once with another key
+func (c *BaseClient) failover(ctx context.Context, attempt int, method string, err error) bool {
+	if c.config.APIKeyPool == nil || !isKeyFailure(err) {
+		return false
+	}
+	if _, ok := apiKeyFromContext(ctx); ok {
+		return false
+	}
+	if attempt >= c.retry.MaxAttempts || !c.retry.isRetryableMethod(method) {
+		return false
+	}
+	return c.config.APIKeyPool.Available()
+}
+
+// send sends the HTTP request of a call, authenticated with apiKey when it is not empty
+func (c *BaseClient) send(ctx context.Context, call *Call, apiKey string) (*Response, error) {
+	req := c.httpClient.R().SetContext(ctx)
+
+	// Set a per-request API key, overriding the client-level key
+	if apiKey != "" {
+		keyType := apiKeyType(c.config)
+		if c.config.APIKeyInQuery {
+			req.SetQueryParam(keyType.QueryParam(), apiKey)
+		} else {
+			req.SetHeader(keyType.Header(), apiKey)
+		}
+	}
 
 	// Set path parameters
-	if len(opts.PathParams) > 0 {
-		req.SetPathParams(opts.PathParams)
+	if len(call.PathParams) > 0 {
+		req.SetPathParams(call.PathParams)
 	}
 
 	// Set query parameters
-	if len(opts.QueryParams) > 0 {
-		req.SetQueryParams(opts.QueryParams)
+	if len(call.QueryParams) > 0 {
+		req.SetQueryParams(call.QueryParams)
 	}
 
 	// Set request body
-	if opts.Body != nil {
-		req.SetBody(opts.Body)
+	if call.Body != nil {
+		req.SetBody(call.Body)
 	}
 
 	// Set request headers
-	if len(opts.Headers) > 0 {
-		req.SetHeaders(opts.Headers)
-	}
-
-	// Set response
-	if result != nil {
-		req.SetResult(result)
+	if len(call.Headers) > 0 {
+		req.SetHeaders(call.Headers)
 	}
 
 	// Execute request
-	res, err := req.Execute(method, path)
+	res, err := req.Execute(call.Method, call.Path)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, wrapContextError(ctxErr)
+		}
+		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 
 	// Check status code
 	if res.StatusCode() >= 300 {
-		return fmt.Errorf("API error: status code %d, response: %s", res.StatusCode(), res.String())
+		return nil, newAPIError(call.Method, call.Path, res.StatusCode(), res.Header(), res.Bytes())
+	}
+
+	response := &Response{
+		StatusCode: res.StatusCode(),
+		Header:     res.Header(),
+		Body:       res.Bytes(),
+	}
+	if res.Request != nil && res.Request.RawRequest != nil {
+		response.URL = redactSecrets(res.Request.RawRequest.URL.String())
+	}
+
+	return response, nil
+}
+
+// decodeResult decodes a JSON response body into result
+func decodeResult(body []byte, result interface{}) error {
+	if result == nil || len(body) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return nil
 }
 
+// wrapContextError wraps context.Canceled or context.DeadlineExceeded so that
+// callers can match it with errors.Is
+func wrapContextError(err error) error {
+	return fmt.Errorf("request aborted: %w", err)
+}
+
 // Get executes a GET request
 func (c *BaseClient) Get(path string, opts *RequestOptions, result interface{}) error {
 	return c.DoRequest("GET", path, opts, result)
 }
 
+// GetWithContext executes a GET request bound to ctx
+func (c *BaseClient) GetWithContext(ctx context.Context, path string, opts *RequestOptions, result interface{}) error {
+	return c.DoRequestWithContext(ctx, "GET", path, opts, result)
+}
+
 // Post executes a POST request
 func (c *BaseClient) Post(path string, opts *RequestOptions, result interface{}) error {
 	return c.DoRequest("POST", path, opts, result)
 }
 
+// PostWithContext executes a POST request bound to ctx
+func (c *BaseClient) PostWithContext(ctx context.Context, path string, opts *RequestOptions, result interface{}) error {
+	return c.DoRequestWithContext(ctx, "POST", path, opts, result)
+}
+
 // Put executes a PUT request
 func (c *BaseClient) Put(path string, opts *RequestOptions, result interface{}) error {
 	return c.DoRequest("PUT", path, opts, result)
 }
 
+// PutWithContext executes a PUT request bound to ctx
+func (c *BaseClient) PutWithContext(ctx context.Context, path string, opts *RequestOptions, result interface{}) error {
+	return c.DoRequestWithContext(ctx, "PUT", path, opts, result)
+}
+
 // Delete executes a DELETE request
 func (c *BaseClient) Delete(path string, opts *RequestOptions, result interface{}) error {
 	return c.DoRequest("DELETE", path, opts, result)
 }
+
+// DeleteWithContext executes a DELETE request bound to ctx
+func (c *BaseClient) DeleteWithContext(ctx context.Context, path string, opts *RequestOptions, result interface{}) error {
+	return c.DoRequestWithContext(ctx, "DELETE", path, opts, result)
+}
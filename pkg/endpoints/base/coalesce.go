@@ -0,0 +1,145 @@
+package base
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WithRequestCoalescing makes concurrent identical GET requests share a single upstream call
+func WithRequestCoalescing() ClientOption {
+	return func(c *Config) {
+		c.CoalesceRequests = true
+	}
+}
+
+// flight is an upstream call shared by concurrent identical requests
+type flight struct {
+	done     chan struct{}
+	response *Response
+	err      error
+	// waiters is the number of callers waiting for the call, guarded by the mutex of the group
+	waiters int
+}
+
+// flightGroup tracks the upstream calls in flight by request key
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// do runs fn unless a call with the same key is already in flight, in which case
+// it waits for that call and returns its outcome. shared reports whether the
+// outcome comes from the call of another caller.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (*Response, error)) (response *Response, shared bool, err error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	if f, ok := g.flights[key]; ok {
+		f.waiters++
+		g.mu.Unlock()
+
+		select {
+		case <-f.done:
+			return f.response, true, f.err
+		case <-ctx.Done():
+			return nil, true, wrapContextError(ctx.Err())
+		}
+	}
+
+	f := &flight{done: make(chan struct{})}
+	g.flights[key] = f
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		close(f.done)
+	}()
+
+	f.response, f.err = fn()
+	return f.response, false, f.err
+}
+
+// executeShared executes a call, sharing the upstream call with concurrent identical GET requests
+// when request coalescing is enabled
+func (c *BaseClient) executeShared(ctx context.Context, call *Call) (*Response, error) {
+	if !c.config.CoalesceRequests || call.Method != http.MethodGet {
+		return c.executeWithRetry(ctx, call)
+	}
+	// Requests with their own API key are not interchangeable with others
+	if _, ok := apiKeyFromContext(ctx); ok {
+		return c.executeWithRetry(ctx, call)
+	}
+
+	key, ok := flightKey(call)
+	if !ok {
+		return c.executeWithRetry(ctx, call)
+	}
+	response, shared, err := c.flights.do(ctx, key, func() (*Response, error) {
+		return c.executeWithRetry(ctx, call)
+	})
+	if !shared {
+		return response, err
+	}
+
+	if err != nil {
+		// The call was aborted by the context of the caller that sent it, not by ours: try again
+		if isContextError(err) && ctx.Err() == nil {
+			return c.executeShared(ctx, call)
+		}
+		return nil, err
+	}
+
+	// Every caller gets its own copy, so that middlewares modifying it do not affect others.
+	// Credits were consumed by the caller that sent the call.
+	return &Response{
+		StatusCode: response.StatusCode,
+		Header:     response.Header.Clone(),
+		Body:       bytes.Clone(response.Body),
+		Attempts:   response.Attempts,
+		URL:        response.URL,
+	}, nil
+}
+
+// flightKey returns the key identifying the upstream call of a request, including its per-call headers
+// since they may change the response, e.g. Accept-Language. ok is false for requests authenticated
+// by their own headers, which are not interchangeable with others.
+func flightKey(call *Call) (key string, ok bool) {
+	key = CacheKey(call.Method, call.Path, call.PathParams, call.QueryParams)
+	if len(call.Headers) == 0 {
+		return key, true
+	}
+
+	headers := make([]string, 0, len(call.Headers))
+	for name, value := range call.Headers {
+		name = http.CanonicalHeaderKey(name)
+		if isAuthHeader(name) {
+			return "", false
+		}
+		headers = append(headers, name+": "+value)
+	}
+	sort.Strings(headers)
+
+	return key + "\n" + strings.Join(headers, "\n"), true
+}
+
+// isAuthHeader reports whether a canonical header name carries credentials
+func isAuthHeader(name string) bool {
+	switch name {
+	case http.CanonicalHeaderKey(ProAPIKeyHeader), http.CanonicalHeaderKey(DemoAPIKeyHeader), "Authorization":
+		return true
+	}
+	return false
+}
+
+// isContextError reports whether err is caused by a canceled or expired context
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,350 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForWaiters waits until n callers wait for the flight of key
+func waitForWaiters(t *testing.T, g *flightGroup, key string, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		f, ok := g.flights[key]
+		waiters := 0
+		if ok {
+			waiters = f.waiters
+		}
+		g.mu.Unlock()
+
+		if waiters >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d waiters of %q", n, key)
+}
+
+// blockingServer answers every request with body once released, counting requests
+type blockingServer struct {
+	*httptest.Server
+	calls    atomic.Int32
+	received chan *http.Request
+	release  chan struct{}
+}
+
+func newBlockingServer(t *testing.T, body string) *blockingServer {
+	t.Helper()
+
+	s := &blockingServer{
+		received: make(chan *http.Request, 16),
+		release:  make(chan struct{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.calls.Add(1)
+		s.received <- r
+		select {
+		case <-s.release:
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Test", "original")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newCoalescingClient returns a client of server coalescing requests, without retries
+func newCoalescingClient(server *blockingServer) *BaseClient {
+	return NewBaseClient(DefaultConfig(), WithBaseURL(server.URL), WithRequestCoalescing(), WithRetryPolicy(NoRetryPolicy()))
+}
+
+// callResult is the outcome of executeShared
+type callResult struct {
+	response *Response
+	err      error
+}
+
+// executeAsync runs executeShared in a goroutine
+func executeAsync(ctx context.Context, c *BaseClient, call *Call) <-chan callResult {
+	results := make(chan callResult, 1)
+	go func() {
+		response, err := c.executeShared(ctx, call)
+		results <- callResult{response: response, err: err}
+	}()
+	return results
+}
+
+func TestFlightGroupErrorFanOut(t *testing.T) {
+	const waiters = 5
+	g := &flightGroup{}
+	wantErr := errors.New("upstream failed")
+	release := make(chan struct{})
+	var calls atomic.Int32
+
+	fn := func() (*Response, error) {
+		calls.Add(1)
+		<-release
+		return nil, wantErr
+	}
+
+	type outcome struct {
+		shared bool
+		err    error
+	}
+	outcomes := make(chan outcome, waiters+1)
+	do := func() {
+		_, shared, err := g.do(context.Background(), "key", fn)
+		outcomes <- outcome{shared: shared, err: err}
+	}
+
+	go do()
+	// The leader holds the flight until released
+	for {
+		g.mu.Lock()
+		_, ok := g.flights["key"]
+		g.mu.Unlock()
+		if ok {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for i := 0; i < waiters; i++ {
+		go do()
+	}
+	waitForWaiters(t, g, "key", waiters)
+	close(release)
+
+	sharedCount := 0
+	for i := 0; i < waiters+1; i++ {
+		o := <-outcomes
+		if !errors.Is(o.err, wantErr) {
+			t.Errorf("error = %v, want %v", o.err, wantErr)
+		}
+		if o.shared {
+			sharedCount++
+		}
+	}
+	if sharedCount != waiters {
+		t.Errorf("%d callers got a shared outcome, want %d", sharedCount, waiters)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("fn called %d times, want 1", got)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.flights) != 0 {
+		t.Errorf("%d flights left after completion", len(g.flights))
+	}
+}
+
+func TestFlightGroupWaiterContextCanceled(t *testing.T) {
+	g := &flightGroup{}
+	release := make(chan struct{})
+	defer close(release)
+
+	go func() {
+		_, _, _ = g.do(context.Background(), "key", func() (*Response, error) {
+			<-release
+			return &Response{}, nil
+		})
+	}()
+	for {
+		g.mu.Lock()
+		_, ok := g.flights["key"]
+		g.mu.Unlock()
+		if ok {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, shared, err := g.do(ctx, "key", func() (*Response, error) {
+		t.Error("waiter sent its own call")
+		return nil, nil
+	})
+	if !shared || !errors.Is(err, context.Canceled) {
+		t.Errorf("shared = %v, error = %v, want a shared context.Canceled", shared, err)
+	}
+}
+
+func TestCoalescingLeaderCanceledWaiterRetries(t *testing.T) {
+	server := newBlockingServer(t, `{"gecko_says":"(V3) To the Moon!"}`)
+	client := newCoalescingClient(server)
+	call := &Call{Method: http.MethodGet, Path: "/ping"}
+	key, _ := flightKey(call)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leader := executeAsync(leaderCtx, client, call)
+	<-server.received
+
+	waiter := executeAsync(context.Background(), client, call)
+	waitForWaiters(t, &client.flights, key, 1)
+
+	cancelLeader()
+	if result := <-leader; !errors.Is(result.err, context.Canceled) {
+		t.Fatalf("leader error = %v, want context.Canceled", result.err)
+	}
+
+	// The waiter sends the call again on its own behalf
+	<-server.received
+	close(server.release)
+
+	result := <-waiter
+	if result.err != nil {
+		t.Fatalf("waiter error = %v, want nil", result.err)
+	}
+	if string(result.response.Body) != `{"gecko_says":"(V3) To the Moon!"}` {
+		t.Errorf("waiter body = %s", result.response.Body)
+	}
+	if got := server.calls.Load(); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+}
+
+func TestCoalescingCopiesResponses(t *testing.T) {
+	const body = `{"gecko_says":"(V3) To the Moon!"}`
+	server := newBlockingServer(t, body)
+	client := newCoalescingClient(server)
+	call := &Call{Method: http.MethodGet, Path: "/ping"}
+	key, _ := flightKey(call)
+
+	leader := executeAsync(context.Background(), client, call)
+	<-server.received
+	waiterA := executeAsync(context.Background(), client, call)
+	waiterB := executeAsync(context.Background(), client, call)
+	waitForWaiters(t, &client.flights, key, 2)
+	close(server.release)
+
+	var responses []*Response
+	for _, results := range []<-chan callResult{leader, waiterA, waiterB} {
+		result := <-results
+		if result.err != nil {
+			t.Fatal(result.err)
+		}
+		responses = append(responses, result.response)
+	}
+	if got := server.calls.Load(); got != 1 {
+		t.Fatalf("server received %d requests, want 1", got)
+	}
+
+	// Modifying a response leaves the others untouched
+	for i, modified := range responses {
+		modified.Body[0] = 'X'
+		modified.Header.Set("X-Test", "modified")
+
+		for j, other := range responses {
+			if i == j {
+				continue
+			}
+			if string(other.Body) != body {
+				t.Errorf("modifying response %d changed the body of response %d to %s", i, j, other.Body)
+			}
+			if got := other.Header.Get("X-Test"); got != "original" {
+				t.Errorf("modifying response %d changed the header of response %d to %s", i, j, got)
+			}
+		}
+
+		modified.Body[0] = body[0]
+		modified.Header.Set("X-Test", "original")
+	}
+}
+
+func TestCoalescingHeaders(t *testing.T) {
+	tests := []struct {
+		name      string
+		headers   [2]map[string]string
+		wantCalls int32
+	}{
+		{
+			name:      "same headers",
+			headers:   [2]map[string]string{{"Accept-Language": "en"}, {"accept-language": "en"}},
+			wantCalls: 1,
+		},
+		{
+			name:      "different headers",
+			headers:   [2]map[string]string{{"Accept-Language": "en"}, {"Accept-Language": "fr"}},
+			wantCalls: 2,
+		},
+		{
+			name:      "headers and no headers",
+			headers:   [2]map[string]string{nil, {"Cache-Control": "no-cache"}},
+			wantCalls: 2,
+		},
+		{
+			name:      "API key header",
+			headers:   [2]map[string]string{{ProAPIKeyHeader: "key-a"}, {ProAPIKeyHeader: "key-a"}},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newBlockingServer(t, `{}`)
+			client := newCoalescingClient(server)
+			first := &Call{Method: http.MethodGet, Path: "/ping", Headers: tt.headers[0]}
+			second := &Call{Method: http.MethodGet, Path: "/ping", Headers: tt.headers[1]}
+
+			results := []<-chan callResult{executeAsync(context.Background(), client, first)}
+			<-server.received
+			results = append(results, executeAsync(context.Background(), client, second))
+
+			if tt.wantCalls == 1 {
+				key, _ := flightKey(first)
+				waitForWaiters(t, &client.flights, key, 1)
+			} else {
+				<-server.received
+			}
+			close(server.release)
+
+			for _, result := range results {
+				if r := <-result; r.err != nil {
+					t.Fatal(r.err)
+				}
+			}
+			if got := server.calls.Load(); got != tt.wantCalls {
+				t.Errorf("server received %d requests, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestFlightKey(t *testing.T) {
+	base := &Call{Method: http.MethodGet, Path: "/coins/{id}", PathParams: map[string]string{"id": "bitcoin"}}
+
+	plain, ok := flightKey(base)
+	if !ok || plain != "GET /coins/bitcoin" {
+		t.Errorf("flightKey = %q, %v, want %q, true", plain, ok, "GET /coins/bitcoin")
+	}
+
+	withHeaders := *base
+	withHeaders.Headers = map[string]string{"cache-control": "no-cache", "Accept-Language": "en"}
+	reordered := *base
+	reordered.Headers = map[string]string{"Accept-Language": "en", "Cache-Control": "no-cache"}
+
+	a, _ := flightKey(&withHeaders)
+	b, _ := flightKey(&reordered)
+	if a != b || a == plain {
+		t.Errorf("keys %q and %q should be equal and differ from %q", a, b, plain)
+	}
+
+	for _, name := range []string{ProAPIKeyHeader, DemoAPIKeyHeader, "authorization"} {
+		call := *base
+		call.Headers = map[string]string{name: "secret"}
+		if _, ok := flightKey(&call); ok {
+			t.Errorf("request with %s header should not be coalesced", name)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package base
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CoinGecko error codes returned in the error_code field of error responses
+const (
+	// ErrorCodeAPIKeyMissing is returned when a request requires an API key but none was sent
+	ErrorCodeAPIKeyMissing = 10002
+	// ErrorCodePlanRestricted is returned when the endpoint is not available on the key's plan
+	ErrorCodePlanRestricted = 10005
+	// ErrorCodeInvalidProAPIKey is returned when a Pro API key is used against the public host
+	ErrorCodeInvalidProAPIKey = 10010
+	// ErrorCodeInvalidDemoAPIKey is returned when a Demo API key is used against the Pro host
+	ErrorCodeInvalidDemoAPIKey = 10011
+)
+
+// requestIDHeaders are the response headers checked, in order, for a request identifier
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "Cf-Ray"}
+
+// APIError is returned when the API responds with a non-2xx status code
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// ErrorCode is the CoinGecko error code parsed from the response body, if any
+	ErrorCode int
+	// ErrorMessage is the CoinGecko error message parsed from the response body, if any
+	ErrorMessage string
+	// Timestamp is the error timestamp parsed from the response body, if any
+	Timestamp string
+	// RetryAfter is the wait duration requested by the Retry-After header, if any
+	RetryAfter time.Duration
+	// Method is the HTTP method of the request
+	Method string
+	// Endpoint is the endpoint path of the request
+	Endpoint string
+	// RequestID is the request identifier taken from the response headers, if any
+	RequestID string
+	// Header is the response header
+	Header http.Header
+	// Body is the raw response body
+	Body []byte
+}
+
+// errorBody covers the error payload formats returned by the API
+type errorBody struct {
+	Status *errorStatus    `json:"status"`
+	Error  json.RawMessage `json:"error"`
+}
+
+type errorStatus struct {
+	ErrorCode    int    `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+	Timestamp    string `json:"timestamp"`
+}
+
+// newAPIError builds an APIError from a failed response
+func newAPIError(method, endpoint string, statusCode int, header http.Header, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+		Header:     header,
+		Body:       body,
+		RetryAfter: parseRetryAfter(header.Get("Retry-After"), time.Now()),
+	}
+
+	for _, name := range requestIDHeaders {
+		if id := header.Get(name); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+
+	apiErr.parseBody()
+	return apiErr
+}
+
+// parseBody fills the CoinGecko error fields from the response body
+func (e *APIError) parseBody() {
+	var payload errorBody
+	if err := json.Unmarshal(e.Body, &payload); err != nil {
+		return
+	}
+
+	status := payload.Status
+	if status == nil && len(payload.Error) > 0 {
+		// Some endpoints nest the status object under "error", others return a plain string
+		var nested errorBody
+		if err := json.Unmarshal(payload.Error, &nested); err == nil && nested.Status != nil {
+			status = nested.Status
+		} else {
+			var message string
+			if err := json.Unmarshal(payload.Error, &message); err == nil {
+				e.ErrorMessage = message
+			}
+		}
+	}
+
+	if status != nil {
+		e.ErrorCode = status.ErrorCode
+		e.ErrorMessage = status.ErrorMessage
+		e.Timestamp = status.Timestamp
+	}
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.ErrorMessage != "" {
+		if e.ErrorCode != 0 {
+			return fmt.Sprintf("API error: %s %s: status code %d, error code %d: %s", e.Method, e.Endpoint, e.StatusCode, e.ErrorCode, e.ErrorMessage)
+		}
+		return fmt.Sprintf("API error: %s %s: status code %d: %s", e.Method, e.Endpoint, e.StatusCode, e.ErrorMessage)
+	}
+	return fmt.Sprintf("API error: %s %s: status code %d, response: %s", e.Method, e.Endpoint, e.StatusCode, strings.TrimSpace(string(e.Body)))
+}
+
+// parseRetryAfter parses a Retry-After header value given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// AsAPIError returns the APIError wrapped in err, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// IsRateLimited reports whether err is an API error caused by exceeding the rate limit
+func IsRateLimited(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && (apiErr.StatusCode == http.StatusTooManyRequests || apiErr.ErrorCode == http.StatusTooManyRequests)
+}
+
+// IsNotFound reports whether err is an API error caused by an unknown resource, such as an unknown coin ID
+func IsNotFound(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is an API error caused by a missing or invalid API key
+func IsUnauthorized(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok || IsPlanRestricted(err) {
+		return false
+	}
+	switch apiErr.ErrorCode {
+	case ErrorCodeAPIKeyMissing, ErrorCodeInvalidProAPIKey, ErrorCodeInvalidDemoAPIKey:
+		return true
+	}
+	return apiErr.StatusCode == http.StatusUnauthorized
+}
+
+// IsPlanRestricted reports whether err is an API error caused by an endpoint not included in the key's plan
+func IsPlanRestricted(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.ErrorCode == ErrorCodePlanRestricted
+}
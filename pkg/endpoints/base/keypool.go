@@ -0,0 +1,276 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultKeyCooldown is how long a rate limited key is retired when the API does not say when to retry
+const DefaultKeyCooldown = time.Minute
+
+// ErrNoAPIKeyAvailable is returned when every key of an APIKeyPool is retired, revoked or out of credits
+var ErrNoAPIKeyAvailable = errors.New("no API key available in pool")
+
+// WeightedAPIKey is an API key with its share of the requests of an APIKeyPool
+type WeightedAPIKey struct {
+	// Key is the API key
+	Key string
+	// Weight is the relative share of requests sent with Key, values below 1 count as 1
+	Weight int
+}
+
+// APIKeyStats describes the usage of a key of an APIKeyPool
+type APIKeyStats struct {
+	// Key is the API key with all but its last characters masked
+	Key string
+	// Weight is the relative share of requests sent with the key
+	Weight int
+	// Requests is the number of requests sent with the key
+	Requests int64
+	// Successes is the number of successful requests
+	Successes int64
+	// RateLimited is the number of requests rejected with 429 Too Many Requests
+	RateLimited int64
+	// Errors is the number of other failed requests
+	Errors int64
+	// Revoked reports whether the key was rejected as invalid and is no longer used
+	Revoked bool
+	// RetiredUntil is the time until which a rate limited key is not used
+	RetiredUntil time.Time
+	// MonthlyCredits is the monthly call credit of the key, 0 until updated
+	MonthlyCredits int
+	// RemainingCredits is the estimated number of remaining monthly credits, valid when CreditsUpdatedAt is set
+	RemainingCredits int
+	// CreditsUpdatedAt is the time credits were last read from the /key endpoint
+	CreditsUpdatedAt time.Time
+}
+
+// pooledKey is a key of an APIKeyPool with its state
+type pooledKey struct {
+	APIKeyStats
+	key     string
+	current int
+}
+
+// available reports whether the key may be used at now
+func (k *pooledKey) available(now time.Time) bool {
+	if k.Revoked || now.Before(k.RetiredUntil) {
+		return false
+	}
+	return k.CreditsUpdatedAt.IsZero() || k.RemainingCredits > 0
+}
+
+// APIKeyPool spreads requests over several API keys of the same type.
+// Keys are selected by smooth weighted round-robin; a key is retired for a
+// cool-down after a 429 response, and revoked after a 401 response.
+type APIKeyPool struct {
+	mu       sync.Mutex
+	keys     []*pooledKey
+	byKey    map[string]*pooledKey
+	cooldown time.Duration
+	now      func() time.Time
+}
+
+// NewAPIKeyPool creates a pool using keys in turn
+func NewAPIKeyPool(keys ...string) *APIKeyPool {
+	weighted := make([]WeightedAPIKey, 0, len(keys))
+	for _, key := range keys {
+		weighted = append(weighted, WeightedAPIKey{Key: key, Weight: 1})
+	}
+	return NewWeightedAPIKeyPool(weighted...)
+}
+
+// NewWeightedAPIKeyPool creates a pool using each key in proportion to its weight
+func NewWeightedAPIKeyPool(keys ...WeightedAPIKey) *APIKeyPool {
+	p := &APIKeyPool{
+		byKey:    make(map[string]*pooledKey, len(keys)),
+		cooldown: DefaultKeyCooldown,
+		now:      time.Now,
+	}
+	for _, key := range keys {
+		if key.Key == "" {
+			continue
+		}
+		if _, ok := p.byKey[key.Key]; ok {
+			continue
+		}
+		weight := key.Weight
+		if weight < 1 {
+			weight = 1
+		}
+		k := &pooledKey{key: key.Key, APIKeyStats: APIKeyStats{Key: maskAPIKey(key.Key), Weight: weight}}
+		p.keys = append(p.keys, k)
+		p.byKey[key.Key] = k
+	}
+	return p
+}
+
+// SetCooldown changes how long a rate limited key is retired when the API does not say when to retry
+func (p *APIKeyPool) SetCooldown(cooldown time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cooldown = cooldown
+}
+
+// Keys returns the keys of the pool, including retired and revoked ones
+func (p *APIKeyPool) Keys() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	keys := make([]string, 0, len(p.keys))
+	for _, k := range p.keys {
+		keys = append(keys, k.key)
+	}
+	return keys
+}
+
+// Stats returns the usage of every key of the pool, in the order the keys were added
+func (p *APIKeyPool) Stats() []APIKeyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]APIKeyStats, 0, len(p.keys))
+	for _, k := range p.keys {
+		stats = append(stats, k.APIKeyStats)
+	}
+	return stats
+}
+
+// Available reports whether at least one key can currently be used
+func (p *APIKeyPool) Available() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	for _, k := range p.keys {
+		if k.available(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateCredits records the monthly and remaining credits of key, as returned by the /key endpoint
+func (p *APIKeyPool) UpdateCredits(key string, monthly, remaining int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if k, ok := p.byKey[key]; ok {
+		k.MonthlyCredits = monthly
+		k.RemainingCredits = remaining
+		k.CreditsUpdatedAt = p.now()
+	}
+}
+
+// Revoke stops using key permanently
+func (p *APIKeyPool) Revoke(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if k, ok := p.byKey[key]; ok {
+		k.Revoked = true
+	}
+}
+
+// acquire selects the key of the next request
+func (p *APIKeyPool) acquire() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	total := 0
+	var selected *pooledKey
+	for _, k := range p.keys {
+		if !k.available(now) {
+			continue
+		}
+		k.current += k.Weight
+		total += k.Weight
+		if selected == nil || k.current > selected.current {
+			selected = k
+		}
+	}
+	if selected == nil {
+		return "", ErrNoAPIKeyAvailable
+	}
+
+	selected.current -= total
+	selected.Requests++
+	return selected.key, nil
+}
+
+// report records the outcome of a request sent with key
+func (p *APIKeyPool) report(key string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k, ok := p.byKey[key]
+	if !ok {
+		return
+	}
+
+	switch {
+	case err == nil:
+		k.Successes++
+		if !k.CreditsUpdatedAt.IsZero() && k.RemainingCredits > 0 {
+			k.RemainingCredits--
+		}
+	case IsRateLimited(err):
+		k.RateLimited++
+		cooldown := p.cooldown
+		if apiErr, ok := AsAPIError(err); ok {
+			if wait := serverRequestedWait(apiErr); wait > 0 {
+				cooldown = wait
+			}
+		}
+		k.RetiredUntil = p.now().Add(cooldown)
+	case IsUnauthorized(err):
+		k.Errors++
+		k.Revoked = true
+	default:
+		k.Errors++
+	}
+}
+
+// isKeyFailure reports whether err is caused by the key a request was sent with
+func isKeyFailure(err error) bool {
+	if apiErr, ok := AsAPIError(err); ok {
+		return apiErr.StatusCode == http.StatusTooManyRequests || IsUnauthorized(err)
+	}
+	return false
+}
+
+// maskAPIKey masks all but the last 4 characters of key
+func maskAPIKey(key string) string {
+	const visible = 4
+	if len(key) <= visible {
+		return redacted
+	}
+	return "..." + key[len(key)-visible:]
+}
+
+// WithAPIKeyPool authenticates requests with the keys of pool, all of the given type
+func WithAPIKeyPool(pool *APIKeyPool, keyType APIKeyType) ClientOption {
+	return func(c *Config) {
+		c.APIKeyPool = pool
+		c.APIKeyType = keyType
+	}
+}
+
+type requestAPIKeyKey struct{}
+
+// WithRequestAPIKey returns a context whose requests are authenticated with apiKey,
+// overriding the configured key and key pool
+func WithRequestAPIKey(ctx context.Context, apiKey string) context.Context {
+	return context.WithValue(ctx, requestAPIKeyKey{}, apiKey)
+}
+
+// apiKeyFromContext returns the API key set by WithRequestAPIKey, if any
+func apiKeyFromContext(ctx context.Context) (string, bool) {
+	apiKey, ok := ctx.Value(requestAPIKeyKey{}).(string)
+	return apiKey, ok && apiKey != ""
+}
@@ -0,0 +1,307 @@
+package base
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock moved forward by tests
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// newTestKeyPool returns a weighted pool driven by clock
+func newTestKeyPool(clock *fakeClock, keys ...WeightedAPIKey) *APIKeyPool {
+	pool := NewWeightedAPIKeyPool(keys...)
+	pool.now = clock.Now
+	return pool
+}
+
+// acquireN acquires n keys and counts them by key
+func acquireN(t *testing.T, pool *APIKeyPool, n int) map[string]int {
+	t.Helper()
+
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		key, err := pool.acquire()
+		if err != nil {
+			t.Fatalf("acquire %d: %v", i, err)
+		}
+		counts[key]++
+	}
+	return counts
+}
+
+func TestAPIKeyPoolWeightedDistribution(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []WeightedAPIKey
+		n    int
+		want map[string]int
+	}{
+		{
+			name: "equal weights",
+			keys: []WeightedAPIKey{{Key: "key-a", Weight: 1}, {Key: "key-b", Weight: 1}, {Key: "key-c", Weight: 1}},
+			n:    9,
+			want: map[string]int{"key-a": 3, "key-b": 3, "key-c": 3},
+		},
+		{
+			name: "weighted",
+			keys: []WeightedAPIKey{{Key: "key-a", Weight: 3}, {Key: "key-b", Weight: 1}},
+			n:    8,
+			want: map[string]int{"key-a": 6, "key-b": 2},
+		},
+		{
+			name: "weights below 1 count as 1",
+			keys: []WeightedAPIKey{{Key: "key-a", Weight: 0}, {Key: "key-b", Weight: -5}},
+			n:    4,
+			want: map[string]int{"key-a": 2, "key-b": 2},
+		},
+		{
+			name: "empty and duplicate keys are ignored",
+			keys: []WeightedAPIKey{{Key: "key-a", Weight: 1}, {Key: "", Weight: 5}, {Key: "key-a", Weight: 5}, {Key: "key-b", Weight: 1}},
+			n:    4,
+			want: map[string]int{"key-a": 2, "key-b": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := newTestKeyPool(newFakeClock(), tt.keys...)
+			got := acquireN(t, pool, tt.n)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("key %s used %d times, want %d (all: %v)", key, got[key], want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIKeyPoolSmoothInterleaving(t *testing.T) {
+	pool := newTestKeyPool(newFakeClock(), WeightedAPIKey{Key: "key-a", Weight: 2}, WeightedAPIKey{Key: "key-b", Weight: 1})
+
+	var got []string
+	for i := 0; i < 6; i++ {
+		key, err := pool.acquire()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, key)
+	}
+
+	want := []string{"key-a", "key-b", "key-a", "key-a", "key-b", "key-a"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestAPIKeyPoolRetiredKeyComesBack(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		cooldown time.Duration
+	}{
+		{
+			name:     "default cool-down",
+			err:      &APIError{StatusCode: http.StatusTooManyRequests},
+			cooldown: time.Minute,
+		},
+		{
+			name:     "Retry-After",
+			err:      &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 5 * time.Minute},
+			cooldown: 5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			pool := newTestKeyPool(clock, WeightedAPIKey{Key: "key-a", Weight: 1}, WeightedAPIKey{Key: "key-b", Weight: 1})
+			pool.SetCooldown(time.Minute)
+
+			pool.report("key-a", tt.err)
+
+			if got := acquireN(t, pool, 4); got["key-a"] != 0 {
+				t.Fatalf("retired key used during cool-down: %v", got)
+			}
+			stats := pool.Stats()
+			if stats[0].RateLimited != 1 || !stats[0].RetiredUntil.Equal(clock.Now().Add(tt.cooldown)) {
+				t.Errorf("stats = %+v, want 1 rate limited request retired until %s", stats[0], clock.Now().Add(tt.cooldown))
+			}
+
+			clock.Advance(tt.cooldown - time.Second)
+			if got := acquireN(t, pool, 2); got["key-a"] != 0 {
+				t.Fatalf("retired key used before the end of its cool-down: %v", got)
+			}
+
+			clock.Advance(time.Second)
+			if got := acquireN(t, pool, 4); got["key-a"] != 2 {
+				t.Errorf("key-a used %d times after its cool-down, want 2", got["key-a"])
+			}
+		})
+	}
+}
+
+func TestAPIKeyPoolRevokedKeyNeverComesBack(t *testing.T) {
+	tests := []struct {
+		name   string
+		revoke func(pool *APIKeyPool)
+	}{
+		{
+			name: "401 response",
+			revoke: func(pool *APIKeyPool) {
+				pool.report("key-a", &APIError{StatusCode: http.StatusUnauthorized})
+			},
+		},
+		{
+			name: "invalid key error code",
+			revoke: func(pool *APIKeyPool) {
+				pool.report("key-a", &APIError{StatusCode: http.StatusBadRequest, ErrorCode: ErrorCodeInvalidProAPIKey})
+			},
+		},
+		{
+			name: "Revoke",
+			revoke: func(pool *APIKeyPool) {
+				pool.Revoke("key-a")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			pool := newTestKeyPool(clock, WeightedAPIKey{Key: "key-a", Weight: 1}, WeightedAPIKey{Key: "key-b", Weight: 1})
+
+			tt.revoke(pool)
+
+			for _, wait := range []time.Duration{0, time.Hour, 30 * 24 * time.Hour} {
+				clock.Advance(wait)
+				if got := acquireN(t, pool, 4); got["key-a"] != 0 {
+					t.Fatalf("revoked key used after %s: %v", wait, got)
+				}
+			}
+			if !pool.Stats()[0].Revoked {
+				t.Error("key-a not reported as revoked")
+			}
+		})
+	}
+}
+
+func TestAPIKeyPoolPlanRestrictionDoesNotRevoke(t *testing.T) {
+	pool := newTestKeyPool(newFakeClock(), WeightedAPIKey{Key: "key-a", Weight: 1})
+
+	pool.report("key-a", &APIError{StatusCode: http.StatusUnauthorized, ErrorCode: ErrorCodePlanRestricted})
+
+	if _, err := pool.acquire(); err != nil {
+		t.Fatalf("key revoked by a plan restriction: %v", err)
+	}
+}
+
+func TestAPIKeyPoolExhausted(t *testing.T) {
+	tests := []struct {
+		name    string
+		exhaust func(pool *APIKeyPool)
+	}{
+		{
+			name: "every key revoked or retired",
+			exhaust: func(pool *APIKeyPool) {
+				pool.report("key-a", &APIError{StatusCode: http.StatusUnauthorized})
+				pool.report("key-b", &APIError{StatusCode: http.StatusTooManyRequests})
+			},
+		},
+		{
+			name: "every key out of credits",
+			exhaust: func(pool *APIKeyPool) {
+				pool.UpdateCredits("key-a", 10000, 0)
+				pool.UpdateCredits("key-b", 10000, 1)
+				pool.report("key-b", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := newTestKeyPool(newFakeClock(), WeightedAPIKey{Key: "key-a", Weight: 1}, WeightedAPIKey{Key: "key-b", Weight: 1})
+
+			tt.exhaust(pool)
+
+			if pool.Available() {
+				t.Error("Available() = true, want false")
+			}
+			if _, err := pool.acquire(); !errors.Is(err, ErrNoAPIKeyAvailable) {
+				t.Errorf("acquire error = %v, want %v", err, ErrNoAPIKeyAvailable)
+			}
+		})
+	}
+}
+
+func TestAPIKeyPoolEmpty(t *testing.T) {
+	pool := NewAPIKeyPool()
+
+	if _, err := pool.acquire(); !errors.Is(err, ErrNoAPIKeyAvailable) {
+		t.Errorf("acquire error = %v, want %v", err, ErrNoAPIKeyAvailable)
+	}
+}
+
+func TestAPIKeyPoolFailover(t *testing.T) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(ProAPIKeyHeader)
+		mu.Lock()
+		calls[key]++
+		mu.Unlock()
+
+		if key == "key-a" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"gecko_says":"(V3) To the Moon!"}`))
+	}))
+	defer server.Close()
+
+	clock := newFakeClock()
+	pool := newTestKeyPool(clock, WeightedAPIKey{Key: "key-a", Weight: 1}, WeightedAPIKey{Key: "key-b", Weight: 1})
+	client := NewBaseClient(DefaultConfig(), WithBaseURL(server.URL), WithAPIKeyPool(pool, APIKeyTypePro))
+
+	for i := 0; i < 3; i++ {
+		if err := client.Get("/ping", nil, nil); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls["key-a"] != 1 || calls["key-b"] != 3 {
+		t.Errorf("calls = %v, want key-a once then key-b only", calls)
+	}
+}
@@ -0,0 +1,192 @@
+package base
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxLoggedBodySize is the number of bytes of error bodies that are logged
+const maxLoggedBodySize = 512
+
+// redacted replaces secrets in logs
+const redacted = "REDACTED"
+
+// apiKeyQueryPattern matches API keys sent as query parameters, e.g. in URLs of transport errors
+var apiKeyQueryPattern = regexp.MustCompile(`(x_cg_[a-z]+_api_key=)[^&\s"']*`)
+
+// LogLevels defines the level of each kind of log record
+type LogLevels struct {
+	// Request is the level of successful requests
+	Request slog.Level
+	// Retry is the level of retried attempts
+	Retry slog.Level
+	// Error is the level of failed requests
+	Error slog.Level
+}
+
+// DefaultLogLevels returns the default log levels:
+// successful requests at debug, retries at warn and failures at error
+func DefaultLogLevels() LogLevels {
+	return LogLevels{
+		Request: slog.LevelDebug,
+		Retry:   slog.LevelWarn,
+		Error:   slog.LevelError,
+	}
+}
+
+// WithLogger logs every request to logger using the default log levels
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Config) {
+		c.Logger = logger
+	}
+}
+
+// WithLogLevels sets the level of each kind of log record
+func WithLogLevels(levels LogLevels) ClientOption {
+	return func(c *Config) {
+		c.LogLevels = &levels
+	}
+}
+
+// requestLogger logs requests with API keys redacted
+type requestLogger struct {
+	logger *slog.Logger
+	levels LogLevels
+}
+
+// newRequestLogger returns the request logger of config, nil when logging is disabled
+func newRequestLogger(config *Config) *requestLogger {
+	if config.Logger == nil {
+		return nil
+	}
+
+	levels := DefaultLogLevels()
+	if config.LogLevels != nil {
+		levels = *config.LogLevels
+	}
+
+	return &requestLogger{logger: config.Logger, levels: levels}
+}
+
+// logAttempt logs the outcome of a single HTTP request
+func (l *requestLogger) logAttempt(ctx context.Context, call *Call, response *Response, duration time.Duration, err error) {
+	if l == nil {
+		return
+	}
+
+	level := l.levels.Request
+	if err != nil {
+		level = l.levels.Error
+	}
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := l.callAttrs(call)
+	attrs = append(attrs, slog.Duration("latency", duration))
+
+	if err == nil {
+		attrs = append(attrs, slog.Int("status", response.StatusCode))
+		l.logger.LogAttrs(ctx, level, "coingecko request", attrs...)
+		return
+	}
+
+	if apiErr, ok := AsAPIError(err); ok {
+		// The error message may embed the whole body, log the truncated body instead
+		attrs = append(attrs, slog.Int("status", apiErr.StatusCode))
+		if apiErr.ErrorCode != 0 {
+			attrs = append(attrs, slog.Int("error_code", apiErr.ErrorCode))
+		}
+		attrs = append(attrs, slog.String("body", truncateBody(apiErr.Body)))
+	} else {
+		attrs = append(attrs, slog.String("error", redactSecrets(err.Error())))
+	}
+	l.logger.LogAttrs(ctx, level, "coingecko request failed", attrs...)
+}
+
+// logRateLimited logs a request rejected by the client-side rate limiter
+func (l *requestLogger) logRateLimited(ctx context.Context, call *Call) {
+	if l == nil || !l.logger.Enabled(ctx, l.levels.Error) {
+		return
+	}
+
+	l.logger.LogAttrs(ctx, l.levels.Error, "coingecko request rejected by rate limiter", l.callAttrs(call)...)
+}
+
+// logRetry logs a failed attempt that is about to be retried
+func (l *requestLogger) logRetry(ctx context.Context, call *Call, attempt RetryAttempt) {
+	if l == nil || !l.logger.Enabled(ctx, l.levels.Retry) {
+		return
+	}
+
+	attrs := l.callAttrs(call)
+	attrs = append(attrs,
+		slog.Int("attempt", attempt.Attempt),
+		slog.Duration("wait", attempt.Wait),
+	)
+	if attempt.StatusCode != 0 {
+		attrs = append(attrs, slog.Int("status", attempt.StatusCode))
+	} else {
+		attrs = append(attrs, slog.String("error", redactSecrets(attempt.Err.Error())))
+	}
+	l.logger.LogAttrs(ctx, l.levels.Retry, "coingecko request retry", attrs...)
+}
+
+// callAttrs returns the attributes describing a call
+func (l *requestLogger) callAttrs(call *Call) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("method", call.Method),
+		slog.String("endpoint", call.Path),
+	}
+	if call.Operation != "" {
+		attrs = append(attrs, slog.String("operation", call.Operation))
+	}
+	if len(call.PathParams) > 0 {
+		attrs = append(attrs, slog.Any("path_params", call.PathParams))
+	}
+	if len(call.QueryParams) > 0 {
+		attrs = append(attrs, slog.Any("query", redactParams(call.QueryParams)))
+	}
+	if len(call.Headers) > 0 {
+		attrs = append(attrs, slog.Any("headers", redactParams(call.Headers)))
+	}
+	return attrs
+}
+
+// redactParams returns a copy of headers or query parameters with API keys redacted
+func redactParams(params map[string]string) map[string]string {
+	redactedParams := make(map[string]string, len(params))
+	for name, value := range params {
+		if isAPIKeyParam(name) {
+			value = redacted
+		}
+		redactedParams[name] = value
+	}
+	return redactedParams
+}
+
+// isAPIKeyParam reports whether a header or query parameter carries an API key
+func isAPIKeyParam(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case DemoAPIKeyHeader, ProAPIKeyHeader, DemoAPIKeyQueryParam, ProAPIKeyQueryParam:
+		return true
+	}
+	return strings.HasPrefix(name, "x_cg_") && strings.HasSuffix(name, "_api_key")
+}
+
+// redactSecrets redacts API keys from text such as URLs
+func redactSecrets(text string) string {
+	return apiKeyQueryPattern.ReplaceAllString(text, "${1}"+redacted)
+}
+
+// truncateBody returns body as text, truncated to maxLoggedBodySize bytes
+func truncateBody(body []byte) string {
+	if len(body) > maxLoggedBodySize {
+		return redactSecrets(string(body[:maxLoggedBodySize])) + "...(truncated)"
+	}
+	return redactSecrets(string(body))
+}
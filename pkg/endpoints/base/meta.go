@@ -0,0 +1,146 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ResponseMeta describes how the response of a call was obtained
+type ResponseMeta struct {
+	// Operation is the logical name of the endpoint method, e.g. "coins.GetCoinsList"
+	Operation string
+	// Endpoint is the endpoint path template, e.g. "/coins/{id}"
+	Endpoint string
+	// URL is the request URL with API keys redacted, empty when no request was sent
+	URL string
+	// StatusCode is the HTTP status code, 0 when no response was received
+	StatusCode int
+	// Header is the response header, nil for responses served from the cache
+	Header http.Header
+	// Body is the raw response body
+	Body []byte
+	// Latency is the duration of the call, including retries and rate limiter waits
+	Latency time.Duration
+	// Attempts is the number of requests sent, including failed ones, 0 for responses served from the cache
+	Attempts int
+	// FromCache reports whether the response was served from the cache
+	FromCache bool
+	// Stale reports whether the response is an expired cache entry served while the circuit breaker is open
+	Stale bool
+	// Credits is the estimated number of API credits consumed by the call
+	Credits int
+}
+
+type responseMetaKey struct{}
+
+type aggregateResponseMetaKey struct{}
+
+// WithResponseMeta returns a context whose calls fill meta once they complete, including failed ones.
+// Each call replaces the meta of the previous one, see WithAggregatedResponseMeta for methods sending several requests.
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// WithAggregatedResponseMeta returns a context whose calls add up in the ResponseMeta set by WithResponseMeta, if any.
+// Latency, Attempts and Credits are summed over the calls, FromCache is set when all of them were served from the cache
+// and Stale when any was; the other fields describe the last call. The ResponseMeta is reset.
+// It is used by methods sending several requests, such as chunked and paged calls.
+func WithAggregatedResponseMeta(ctx context.Context) context.Context {
+	meta := responseMetaFromContext(ctx)
+	if meta == nil {
+		return ctx
+	}
+	*meta = ResponseMeta{}
+	return context.WithValue(ctx, aggregateResponseMetaKey{}, true)
+}
+
+// responseMetaFromContext returns the ResponseMeta set by WithResponseMeta, if any
+func responseMetaFromContext(ctx context.Context) *ResponseMeta {
+	meta, _ := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	return meta
+}
+
+// aggregatesResponseMeta reports whether the calls of ctx add up in their ResponseMeta
+func aggregatesResponseMeta(ctx context.Context) bool {
+	aggregate, _ := ctx.Value(aggregateResponseMetaKey{}).(bool)
+	return aggregate
+}
+
+type callStatsKey struct{}
+
+// callStats counts the attempts of a call and the credits they consumed, failed attempts included
+type callStats struct {
+	attempts int
+	credits  int
+}
+
+// withCallStats returns a context whose attempts are counted in stats
+func withCallStats(ctx context.Context, stats *callStats) context.Context {
+	return context.WithValue(ctx, callStatsKey{}, stats)
+}
+
+// callStatsFromContext returns the callStats set by withCallStats, if any
+func callStatsFromContext(ctx context.Context) *callStats {
+	stats, _ := ctx.Value(callStatsKey{}).(*callStats)
+	return stats
+}
+
+// count records an attempt ending with response or err
+func (s *callStats) count(response *Response, err error) {
+	if s == nil {
+		return
+	}
+
+	s.attempts++
+	if response != nil {
+		s.credits += creditCost(response.StatusCode)
+	} else if apiErr, ok := AsAPIError(err); ok {
+		s.credits += creditCost(apiErr.StatusCode)
+	}
+}
+
+// fillResponseMeta fills meta with the outcome of a call, whose attempts were counted in stats
+func fillResponseMeta(meta *ResponseMeta, call *Call, response *Response, latency time.Duration, stats *callStats, aggregate bool, err error) {
+	previous := *meta
+	*meta = ResponseMeta{
+		Operation: call.Operation,
+		Endpoint:  call.Path,
+		Latency:   latency,
+		Attempts:  stats.attempts,
+		Credits:   stats.credits,
+	}
+
+	if response != nil {
+		meta.URL = response.URL
+		meta.StatusCode = response.StatusCode
+		meta.Header = response.Header
+		meta.Body = response.Body
+		meta.Attempts = response.Attempts
+		meta.FromCache = response.FromCache
+		meta.Stale = response.Stale
+		meta.Credits = response.Credits
+	} else if apiErr, ok := AsAPIError(err); ok {
+		meta.StatusCode = apiErr.StatusCode
+		meta.Header = apiErr.Header
+		meta.Body = apiErr.Body
+	}
+
+	// The first call of an aggregate finds the meta reset
+	if aggregate && previous.Endpoint != "" {
+		meta.Latency += previous.Latency
+		meta.Attempts += previous.Attempts
+		meta.Credits += previous.Credits
+		meta.FromCache = meta.FromCache && previous.FromCache
+		meta.Stale = meta.Stale || previous.Stale
+	}
+}
+
+// creditCost returns the estimated API credits consumed by a request answered with statusCode:
+// every request answered by the API costs one credit, except rate limited and failed ones
+func creditCost(statusCode int) int {
+	if statusCode <= 0 || statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError {
+		return 0
+	}
+	return 1
+}
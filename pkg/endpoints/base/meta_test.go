@@ -0,0 +1,189 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newStatusServer answers its requests with statuses in turn, repeating the last one
+func newStatusServer(t *testing.T, statuses ...int) *httptest.Server {
+	t.Helper()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		status := statuses[len(statuses)-1]
+		if n <= len(statuses) {
+			status = statuses[n-1]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"gecko_says":"(V3) To the Moon!"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// testRetryPolicy retries server errors up to maxAttempts attempts without waiting
+func testRetryPolicy(maxAttempts int) *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = maxAttempts
+	policy.InitialWait = time.Millisecond
+	policy.MaxWait = time.Millisecond
+	policy.Jitter = 0
+	return policy
+}
+
+func TestResponseMeta(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		maxAttempts  int
+		wantErr      bool
+		wantStatus   int
+		wantAttempts int
+		wantCredits  int
+	}{
+		{
+			name:         "success",
+			statuses:     []int{http.StatusOK},
+			maxAttempts:  3,
+			wantStatus:   http.StatusOK,
+			wantAttempts: 1,
+			wantCredits:  1,
+		},
+		{
+			name:         "success after retries",
+			statuses:     []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK},
+			maxAttempts:  3,
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+			wantCredits:  1,
+		},
+		{
+			name:         "client error",
+			statuses:     []int{http.StatusNotFound},
+			maxAttempts:  3,
+			wantErr:      true,
+			wantStatus:   http.StatusNotFound,
+			wantAttempts: 1,
+			wantCredits:  1,
+		},
+		{
+			name:         "retries exhausted",
+			statuses:     []int{http.StatusInternalServerError},
+			maxAttempts:  3,
+			wantErr:      true,
+			wantStatus:   http.StatusInternalServerError,
+			wantAttempts: 3,
+			wantCredits:  0,
+		},
+		{
+			name:         "client error after retries",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusUnauthorized},
+			maxAttempts:  3,
+			wantErr:      true,
+			wantStatus:   http.StatusUnauthorized,
+			wantAttempts: 2,
+			wantCredits:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStatusServer(t, tt.statuses...)
+			client := NewBaseClient(DefaultConfig(), WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy(tt.maxAttempts)))
+
+			var meta ResponseMeta
+			ctx := WithResponseMeta(context.Background(), &meta)
+			opts := &RequestOptions{Operation: "ping.Ping", QueryParams: map[string]string{"x": "1"}}
+			err := client.GetWithContext(ctx, "/ping", opts, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if meta.Operation != "ping.Ping" || meta.Endpoint != "/ping" {
+				t.Errorf("operation = %q, endpoint = %q", meta.Operation, meta.Endpoint)
+			}
+			if meta.StatusCode != tt.wantStatus || meta.Attempts != tt.wantAttempts || meta.Credits != tt.wantCredits {
+				t.Errorf("status = %d, attempts = %d, credits = %d, want %d, %d, %d",
+					meta.StatusCode, meta.Attempts, meta.Credits, tt.wantStatus, tt.wantAttempts, tt.wantCredits)
+			}
+			if len(meta.Body) == 0 || meta.Header.Get("Content-Type") != "application/json" || meta.Latency <= 0 {
+				t.Errorf("meta = %+v, want the body, header and latency of the last response", meta)
+			}
+			if !tt.wantErr && meta.URL != server.URL+"/ping?x=1" {
+				t.Errorf("URL = %q, want %s/ping?x=1", meta.URL, server.URL)
+			}
+			if meta.FromCache || meta.Stale {
+				t.Errorf("meta = %+v, want a response from the API", meta)
+			}
+		})
+	}
+}
+
+func TestResponseMetaFromCache(t *testing.T) {
+	server := newCountingServer(t)
+	client := newCachingClient(server, NewMemoryCache(10), newFakeClock())
+
+	var meta ResponseMeta
+	ctx := WithResponseMeta(context.Background(), &meta)
+	ping(t, ctx, client)
+	if meta.FromCache || meta.Attempts != 1 || meta.Credits != 1 {
+		t.Errorf("meta = %+v, want one request sent", meta)
+	}
+
+	ping(t, ctx, client)
+	if !meta.FromCache || meta.Stale || meta.Attempts != 0 || meta.Credits != 0 || meta.StatusCode != http.StatusOK {
+		t.Errorf("meta = %+v, want a fresh cached response", meta)
+	}
+}
+
+func TestAggregatedResponseMeta(t *testing.T) {
+	server := newCountingServer(t)
+	client := newCachingClient(server, NewMemoryCache(10), newFakeClock(), WithCacheTTL("/other", time.Minute))
+	get := func(ctx context.Context, path string) {
+		t.Helper()
+		if err := client.GetWithContext(ctx, path, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	meta := ResponseMeta{Attempts: 10, Credits: 10}
+	ctx := WithAggregatedResponseMeta(WithResponseMeta(context.Background(), &meta))
+	if meta.Attempts != 0 {
+		t.Fatalf("meta = %+v, want it reset", meta)
+	}
+
+	// Calls add up, the last one describing the response
+	get(ctx, "/ping")
+	get(ctx, "/ping")
+	get(ctx, "/other")
+	if meta.Endpoint != "/other" || meta.Attempts != 2 || meta.Credits != 2 || meta.FromCache {
+		t.Errorf("meta = %+v, want 2 attempts and credits ending with /other", meta)
+	}
+
+	ctx = WithAggregatedResponseMeta(WithResponseMeta(context.Background(), &meta))
+	get(ctx, "/ping")
+	get(ctx, "/other")
+	if meta.Attempts != 0 || !meta.FromCache {
+		t.Errorf("meta = %+v, want every response from the cache", meta)
+	}
+
+	// Without aggregation each call replaces the meta
+	ctx = WithResponseMeta(context.Background(), &meta)
+	get(WithCacheBypass(ctx), "/ping")
+	get(WithCacheBypass(ctx), "/other")
+	if meta.Endpoint != "/other" || meta.Attempts != 1 {
+		t.Errorf("meta = %+v, want the last call only", meta)
+	}
+
+	// A context without meta is left as is
+	if background := context.Background(); WithAggregatedResponseMeta(background) != background {
+		t.Error("context without meta wrapped")
+	}
+}
@@ -0,0 +1,74 @@
+package base
+
+import (
+	"net/http"
+	"time"
+)
+
+// Metrics receives measurements of the calls made by a BaseClient.
+// Endpoints are reported as path templates, e.g. "/coins/{id}".
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest records an HTTP request with its status class ("2xx", "4xx", "error", ...) and latency
+	ObserveRequest(endpoint, statusClass string, duration time.Duration)
+	// IncRetry records a retried attempt
+	IncRetry(endpoint string)
+	// IncRateLimited records a request rejected by the client-side limiter ("client") or by the API ("server")
+	IncRateLimited(endpoint, source string)
+	// IncCacheHit records a call served from the cache
+	IncCacheHit(endpoint string)
+	// IncCacheMiss records a cacheable call not served from the cache
+	IncCacheMiss(endpoint string)
+	// AddCredits records the estimated API credits consumed
+	AddCredits(endpoint string, credits float64)
+}
+
+// Rate limit sources reported to Metrics.IncRateLimited
+const (
+	RateLimitSourceClient = "client"
+	RateLimitSourceServer = "server"
+)
+
+// NopMetrics is a Metrics implementation that discards every measurement
+type NopMetrics struct{}
+
+func (NopMetrics) ObserveRequest(endpoint, statusClass string, duration time.Duration) {}
+func (NopMetrics) IncRetry(endpoint string)                                            {}
+func (NopMetrics) IncRateLimited(endpoint, source string)                              {}
+func (NopMetrics) IncCacheHit(endpoint string)                                         {}
+func (NopMetrics) IncCacheMiss(endpoint string)                                        {}
+func (NopMetrics) AddCredits(endpoint string, credits float64)                         {}
+
+// WithMetrics reports measurements of every call to metrics
+func WithMetrics(metrics Metrics) ClientOption {
+	return func(c *Config) {
+		c.Metrics = metrics
+	}
+}
+
+// StatusClass returns the class of an HTTP status code, e.g. "2xx", or "error" when no response was received
+func StatusClass(statusCode int) string {
+	if statusCode < 100 || statusCode > 599 {
+		return "error"
+	}
+	return string(rune('0'+statusCode/100)) + "xx"
+}
+
+// recordAttempt reports the outcome of a single HTTP request to metrics
+func recordAttempt(metrics Metrics, endpoint string, response *Response, duration time.Duration, err error) {
+	statusCode := 0
+	if response != nil {
+		statusCode = response.StatusCode
+	} else if apiErr, ok := AsAPIError(err); ok {
+		statusCode = apiErr.StatusCode
+	}
+
+	metrics.ObserveRequest(endpoint, StatusClass(statusCode), duration)
+
+	if statusCode == http.StatusTooManyRequests {
+		metrics.IncRateLimited(endpoint, RateLimitSourceServer)
+	}
+	if credits := creditCost(statusCode); credits > 0 {
+		metrics.AddCredits(endpoint, float64(credits))
+	}
+}
@@ -0,0 +1,80 @@
+package base
+
+import (
+	"context"
+	"net/http"
+)
+
+// Call describes a logical API call as seen by middlewares.
+// Middlewares may modify it before passing it on.
+type Call struct {
+	// Operation is the logical name of the endpoint method, e.g. "coins.GetCoinsList"
+	Operation string
+	// Method is the HTTP method
+	Method string
+	// Path is the endpoint path template, e.g. "/coins/{id}"
+	Path string
+	// PathParams are the values substituted into Path
+	PathParams map[string]string
+	// QueryParams are the query parameters
+	QueryParams map[string]string
+	// Headers are the request headers
+	Headers map[string]string
+	// Body is the request body
+	Body interface{}
+	// Result is the value the response body is decoded into
+	Result interface{}
+}
+
+// Response is the outcome of a successful call
+type Response struct {
+	// StatusCode is the HTTP status code
+	StatusCode int
+	// Header is the response header, nil for responses served from the cache
+	Header http.Header
+	// Body is the raw response body
+	Body []byte
+	// Result is the decoded response body, nil until the body has been decoded
+	Result interface{}
+	// Attempts is the number of attempts made, 0 for responses served from the cache
+	Attempts int
+	// FromCache reports whether the response was served from the cache
+	FromCache bool
+	// URL is the request URL with API keys redacted, empty for responses served from the cache
+	URL string
+	// Credits is the estimated number of API credits consumed, including failed attempts
+	Credits int
+	// Stale reports whether the response is an expired cache entry served while the circuit breaker is open
+	Stale bool
+
+	// decoded reports whether Body has been decoded into the call's Result
+	decoded bool
+}
+
+// RoundTrip performs a call and returns its response.
+// Failed calls return an error, such as an *APIError, and a nil response.
+type RoundTrip func(ctx context.Context, call *Call) (*Response, error)
+
+// Middleware wraps a RoundTrip to observe, modify or short-circuit calls.
+// A middleware short-circuits by returning a Response without calling next;
+// its Body is then decoded into the call's Result.
+type Middleware func(next RoundTrip) RoundTrip
+
+// chainMiddlewares wraps transport with middlewares, the first middleware being the outermost
+func chainMiddlewares(transport RoundTrip, middlewares []Middleware) RoundTrip {
+	roundTrip := transport
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		roundTrip = middlewares[i](roundTrip)
+	}
+	return roundTrip
+}
+
+// WithMiddleware appends middlewares to the chain wrapping every call
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Config) {
+		// Copy the slice so that configs sharing it are not modified
+		chain := make([]Middleware, 0, len(c.Middlewares)+len(middlewares))
+		chain = append(chain, c.Middlewares...)
+		c.Middlewares = append(chain, middlewares...)
+	}
+}
@@ -0,0 +1,156 @@
+package base
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Environment variables read by ConfigFromEnv
+const (
+	EnvAPIKey     = "COINGECKO_API_KEY"
+	EnvAPIKeyType = "COINGECKO_API_KEY_TYPE"
+	EnvBaseURL    = "COINGECKO_BASE_URL"
+)
+
+// ClientOption defines client configuration options
+type ClientOption func(*Config)
+
+// ConfigFromEnv returns the default configuration updated from the environment:
+// COINGECKO_API_KEY, COINGECKO_API_KEY_TYPE ("demo" or "pro") and COINGECKO_BASE_URL
+func ConfigFromEnv() *Config {
+	config := DefaultConfig()
+
+	if apiKey := strings.TrimSpace(os.Getenv(EnvAPIKey)); apiKey != "" {
+		config.APIKey = apiKey
+	}
+	if keyType := strings.ToLower(strings.TrimSpace(os.Getenv(EnvAPIKeyType))); keyType != "" {
+		config.APIKeyType = APIKeyType(keyType)
+	}
+	if baseURL := strings.TrimSpace(os.Getenv(EnvBaseURL)); baseURL != "" {
+		config.BaseURL = baseURL
+	}
+
+	return config
+}
+
+// WithBaseURL sets the base URL of the API
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Config) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithTimeout sets the timeout of each request attempt
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Config) {
+		c.Timeout = timeout
+	}
+}
+
+// WithRetry sets the number of retries and the wait duration before the first retry
+func WithRetry(count int, waitTime time.Duration) ClientOption {
+	return func(c *Config) {
+		c.RetryCount = count
+		c.RetryWaitTime = waitTime
+		c.RetryPolicy = nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Config) {
+		c.RetryPolicy = policy
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Config) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithTransport sets the HTTP transport used to send requests
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Config) {
+		c.Transport = transport
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Config) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithProxy sends requests through the proxy at proxyURL
+func WithProxy(proxyURL string) ClientOption {
+	return func(c *Config) {
+		c.ProxyURL = proxyURL
+	}
+}
+
+// WithAPIKey sets the API key, keeping the configured key type
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *Config) {
+		c.APIKey = apiKey
+	}
+}
+
+// WithDemoAPIKey authenticates with a Demo plan key sent in the x-cg-demo-api-key header
+func WithDemoAPIKey(apiKey string) ClientOption {
+	return func(c *Config) {
+		c.APIKey = apiKey
+		c.APIKeyType = APIKeyTypeDemo
+		c.APIKeyInQuery = false
+	}
+}
+
+// WithProAPIKey authenticates with a paid plan key sent in the x-cg-pro-api-key header
+func WithProAPIKey(apiKey string) ClientOption {
+	return func(c *Config) {
+		c.APIKey = apiKey
+		c.APIKeyType = APIKeyTypePro
+		c.APIKeyInQuery = false
+	}
+}
+
+// WithAPIKeyInQuery authenticates with a key of the given type sent as a query parameter instead of a header
+func WithAPIKeyInQuery(apiKey string, keyType APIKeyType) ClientOption {
+	return func(c *Config) {
+		c.APIKey = apiKey
+		c.APIKeyType = keyType
+		c.APIKeyInQuery = true
+	}
+}
+
+// WithRateLimit sets the maximum number of requests per minute and the limiter policy
+func WithRateLimit(requestsPerMinute int, policy RateLimitPolicy) ClientOption {
+	return func(c *Config) {
+		c.RateLimit = requestsPerMinute
+		c.RateLimitPolicy = policy
+	}
+}
+
+// WithCache stores GET responses in cache
+func WithCache(cache Cache) ClientOption {
+	return func(c *Config) {
+		c.Cache = cache
+	}
+}
+
+// WithCacheTTL overrides the cache TTL of an endpoint path template, a zero TTL disables caching
+func WithCacheTTL(path string, ttl time.Duration) ClientOption {
+	return func(c *Config) {
+		// Copy the map so that configs sharing it are not modified
+		ttls := make(map[string]time.Duration, len(c.CacheTTLs)+1)
+		for p, t := range c.CacheTTLs {
+			ttls[p] = t
+		}
+		ttls[path] = ttl
+		c.CacheTTLs = ttls
+	}
+}
@@ -0,0 +1,209 @@
+package base
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// queryTag is the struct tag naming the query parameter of a request field
+const queryTag = "query"
+
+// queryField is a request field encoded as a query parameter
+type queryField struct {
+	name     string
+	index    []int
+	required bool
+}
+
+// queryFields caches the query fields of request types
+var queryFields sync.Map // map[reflect.Type][]queryField
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// EncodeQuery returns the query parameters of request, a struct or a pointer to one,
+// built from the `query` tags of its fields:
+//
+//	type Request struct {
+//		ID      string   `query:"-"`                // path parameter
+//		IDs     []string `query:"ids"`              // comma-joined
+//		Tickers bool     `query:"tickers,required"` // sent even when false
+//	}
+//
+// Zero values and empty slices are omitted unless the field is marked required, so the API applies its own
+// defaults; non-nil pointer fields are sent even when they point to a zero value. Fields without
+// tag are ignored, and fields of embedded structs are encoded as fields of request.
+// Bools are encoded as "true" or "false", times as Unix timestamps in seconds.
+func EncodeQuery(request interface{}) (map[string]string, error) {
+	params := make(map[string]string)
+
+	v := reflect.ValueOf(request)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return params, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot encode query from %T: not a struct", request)
+	}
+
+	fields, err := cachedQueryFields(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		fv, ok := fieldByIndex(v, field.index)
+		if !ok {
+			continue
+		}
+
+		explicit := false
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+			explicit = true
+		}
+		if !explicit && !field.required && (fv.IsZero() || fv.Kind() == reflect.Slice && fv.Len() == 0) {
+			continue
+		}
+
+		value, err := formatQueryValue(fv)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode query parameter %q: %w", field.name, err)
+		}
+		params[field.name] = value
+	}
+
+	return params, nil
+}
+
+// cachedQueryFields returns the query fields of t, a struct type
+func cachedQueryFields(t reflect.Type) ([]queryField, error) {
+	if fields, ok := queryFields.Load(t); ok {
+		return fields.([]queryField), nil
+	}
+
+	fields, err := collectQueryFields(t, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	queryFields.Store(t, fields)
+	return fields, nil
+}
+
+// collectQueryFields returns the tagged fields of t and of its embedded structs
+func collectQueryFields(t reflect.Type, index []int) ([]queryField, error) {
+	var fields []queryField
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		tag, tagged := sf.Tag.Lookup(queryTag)
+		if tag == "-" {
+			continue
+		}
+
+		if !tagged {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if sf.Anonymous && ft.Kind() == reflect.Struct {
+				embedded, err := collectQueryFields(ft, fieldIndex)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, embedded...)
+			}
+			continue
+		}
+
+		if !sf.IsExported() {
+			return nil, fmt.Errorf("cannot encode query parameter of unexported field %s.%s", t.Name(), sf.Name)
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			return nil, fmt.Errorf("missing query parameter name on field %s.%s", t.Name(), sf.Name)
+		}
+
+		field := queryField{name: name, index: fieldIndex}
+		for _, option := range strings.Split(options, ",") {
+			switch option {
+			case "":
+			case "required":
+				field.required = true
+			default:
+				return nil, fmt.Errorf("unknown query option %q on field %s.%s", option, t.Name(), sf.Name)
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// fieldByIndex returns the field of v at index, reporting false when it is inside a nil embedded pointer
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// formatQueryValue formats a query parameter value, comma-joining slices
+func formatQueryValue(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		values := make([]string, v.Len())
+		for i := range values {
+			value, err := formatScalarQueryValue(v.Index(i))
+			if err != nil {
+				return "", err
+			}
+			values[i] = value
+		}
+		return strings.Join(values, ","), nil
+	}
+
+	return formatScalarQueryValue(v)
+}
+
+// formatScalarQueryValue formats a single query parameter value
+func formatScalarQueryValue(v reflect.Value) (string, error) {
+	if v.Type() == timeType {
+		return strconv.FormatInt(v.Interface().(time.Time).Unix(), 10), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
@@ -0,0 +1,129 @@
+package base
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type queryPage struct {
+	Page    int `query:"page"`
+	PerPage int `query:"per_page"`
+}
+
+type queryRequest struct {
+	*queryPage
+	ID        string     `query:"-"`
+	IDs       []string   `query:"ids"`
+	Days      []int      `query:"days"`
+	Order     string     `query:"order"`
+	Sparkline bool       `query:"sparkline"`
+	Tickers   bool       `query:"tickers,required"`
+	Limit     int        `query:"limit,required"`
+	Precision *int       `query:"precision"`
+	Localized *bool      `query:"localization"`
+	Ratio     float64    `query:"ratio"`
+	From      time.Time  `query:"from"`
+	To        *time.Time `query:"to"`
+	Note      string
+}
+
+func TestEncodeQuery(t *testing.T) {
+	zero, no := 0, false
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		request interface{}
+		want    map[string]string
+	}{
+		{
+			name:    "zero values omitted, required sent",
+			request: &queryRequest{},
+			want:    map[string]string{"tickers": "false", "limit": "0"},
+		},
+		{
+			name:    "path and untagged fields ignored",
+			request: &queryRequest{ID: "bitcoin", Note: "note"},
+			want:    map[string]string{"tickers": "false", "limit": "0"},
+		},
+		{
+			name:    "comma-joined slices",
+			request: queryRequest{IDs: []string{"bitcoin", "ethereum"}, Days: []int{1, 7, 30}},
+			want:    map[string]string{"ids": "bitcoin,ethereum", "days": "1,7,30", "tickers": "false", "limit": "0"},
+		},
+		{
+			name:    "empty slices omitted",
+			request: &queryRequest{IDs: []string{}},
+			want:    map[string]string{"tickers": "false", "limit": "0"},
+		},
+		{
+			name:    "scalars",
+			request: &queryRequest{Order: "market_cap_desc", Sparkline: true, Tickers: true, Limit: 10, Ratio: 0.25},
+			want:    map[string]string{"order": "market_cap_desc", "sparkline": "true", "tickers": "true", "limit": "10", "ratio": "0.25"},
+		},
+		{
+			name:    "non-nil pointers to zero values sent",
+			request: &queryRequest{Precision: &zero, Localized: &no},
+			want:    map[string]string{"precision": "0", "localization": "false", "tickers": "false", "limit": "0"},
+		},
+		{
+			name:    "times as Unix seconds",
+			request: &queryRequest{From: date, To: &date},
+			want:    map[string]string{"from": "1704164645", "to": "1704164645", "tickers": "false", "limit": "0"},
+		},
+		{
+			name:    "embedded struct fields",
+			request: &queryRequest{queryPage: &queryPage{Page: 2}},
+			want:    map[string]string{"page": "2", "tickers": "false", "limit": "0"},
+		},
+		{
+			name:    "nil request",
+			request: (*queryRequest)(nil),
+			want:    map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeQuery(tt.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("EncodeQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeQueryErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		request interface{}
+		want    string
+	}{
+		{name: "not a struct", request: "ids=bitcoin", want: "not a struct"},
+		{name: "missing name", request: &struct {
+			IDs []string `query:",required"`
+		}{}, want: "missing query parameter name"},
+		{name: "unknown option", request: &struct {
+			IDs []string `query:"ids,omitempty"`
+		}{}, want: `unknown query option "omitempty"`},
+		{name: "unexported field", request: &struct {
+			ids []string `query:"ids"`
+		}{}, want: "unexported field"},
+		{name: "unsupported type", request: &struct {
+			Filter map[string]string `query:"filter"`
+		}{Filter: map[string]string{"a": "b"}}, want: "unsupported type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := EncodeQuery(tt.request); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
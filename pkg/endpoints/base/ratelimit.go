@@ -0,0 +1,148 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Requests per minute allowed by each CoinGecko plan
+const (
+	// DemoPlanRateLimit is the rate limit of the Demo (free) plan
+	DemoPlanRateLimit = 30
+	// AnalystPlanRateLimit is the rate limit of the Analyst plan
+	AnalystPlanRateLimit = 500
+	// LitePlanRateLimit is the rate limit of the Lite plan
+	LitePlanRateLimit = 500
+	// ProPlanRateLimit is the rate limit of the Pro plan
+	ProPlanRateLimit = 1000
+)
+
+// ErrRateLimited is returned when the client-side rate limiter rejects a request
+var ErrRateLimited = errors.New("client-side rate limit exceeded")
+
+// RateLimitPolicy defines how the rate limiter behaves when no token is available
+type RateLimitPolicy int
+
+const (
+	// RateLimitWait blocks until a token is available or the context is done
+	RateLimitWait RateLimitPolicy = iota
+	// RateLimitFailFast returns ErrRateLimited immediately
+	RateLimitFailFast
+)
+
+// PlanRateLimit returns the requests per minute allowed by the named plan, or 0 if the plan is unknown
+func PlanRateLimit(plan string) int {
+	switch strings.ToLower(strings.TrimSpace(plan)) {
+	case "demo", "free":
+		return DemoPlanRateLimit
+	case "analyst":
+		return AnalystPlanRateLimit
+	case "lite":
+		return LitePlanRateLimit
+	case "pro":
+		return ProPlanRateLimit
+	default:
+		return 0
+	}
+}
+
+// RateLimiter is a token bucket limiter shared by every request of a BaseClient
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    int
+	tokens   float64
+	last     time.Time
+	policy   RateLimitPolicy
+}
+
+// NewRateLimiter creates a rate limiter allowing requestsPerMinute requests with the given burst size.
+// A requestsPerMinute of 0 disables limiting.
+func NewRateLimiter(requestsPerMinute, burst int, policy RateLimitPolicy) *RateLimiter {
+	l := &RateLimiter{policy: policy}
+	l.SetRate(requestsPerMinute, burst)
+	return l
+}
+
+// SetRate changes the allowed requests per minute and burst size.
+// A requestsPerMinute of 0 disables limiting.
+func (l *RateLimiter) SetRate(requestsPerMinute, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	l.interval = 0
+	if requestsPerMinute > 0 {
+		l.interval = time.Minute / time.Duration(requestsPerMinute)
+	}
+	l.burst = burst
+	l.tokens = float64(burst)
+	l.last = time.Now()
+}
+
+// SetPolicy changes the behavior of the limiter when no token is available
+func (l *RateLimiter) SetPolicy(policy RateLimitPolicy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.policy = policy
+}
+
+// RequestsPerMinute returns the configured rate, or 0 if limiting is disabled
+func (l *RateLimiter) RequestsPerMinute() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.interval == 0 {
+		return 0
+	}
+	return int(time.Minute / l.interval)
+}
+
+// Wait takes a token, blocking or failing according to the limiter policy
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		wait := l.reserve(time.Now())
+		policy := l.policy
+		l.mu.Unlock()
+
+		if wait == 0 {
+			return nil
+		}
+		if policy == RateLimitFailFast {
+			return ErrRateLimited
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns 0,
+// otherwise it returns the duration until the next token is available
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	if l.interval == 0 {
+		return 0
+	}
+
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) * float64(l.interval))
+}
@@ -0,0 +1,197 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryAttempt describes a failed attempt that is about to be retried
+type RetryAttempt struct {
+	// Attempt is the number of the failed attempt, starting at 1
+	Attempt int
+	// Method is the HTTP method of the request
+	Method string
+	// Endpoint is the endpoint path of the request
+	Endpoint string
+	// StatusCode is the HTTP status code of the failed attempt, 0 for transport errors
+	StatusCode int
+	// Err is the error of the failed attempt
+	Err error
+	// Wait is the duration waited before the next attempt
+	Wait time.Duration
+}
+
+// RetryPolicy defines when and how failed requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one, values below 2 disable retries
+	MaxAttempts int
+	// InitialWait is the wait duration before the first retry, doubled on every following retry
+	InitialWait time.Duration
+	// MaxWait is the maximum wait duration between attempts.
+	// A server-requested wait longer than MaxWait stops retrying.
+	MaxWait time.Duration
+	// Jitter is the fraction (0 to 1) of each wait duration that is randomized
+	Jitter float64
+	// RespectRetryAfter waits for the duration requested by Retry-After or x-ratelimit-reset headers
+	RespectRetryAfter bool
+	// RetryNetworkErrors retries attempts that failed without a response
+	RetryNetworkErrors bool
+	// RetryableStatusCodes are the HTTP status codes that are retried
+	RetryableStatusCodes []int
+	// RetryableMethods are the HTTP methods that are retried
+	RetryableMethods []string
+	// OnRetry is called before waiting for each retry
+	OnRetry func(attempt RetryAttempt)
+}
+
+// DefaultRetryPolicy returns the default retry policy
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:        4,
+		InitialWait:        1 * time.Second,
+		MaxWait:            60 * time.Second,
+		Jitter:             0.2,
+		RespectRetryAfter:  true,
+		RetryNetworkErrors: true,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableMethods: []string{http.MethodGet, http.MethodHead},
+	}
+}
+
+// NoRetryPolicy returns a policy that never retries
+func NoRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 1}
+}
+
+// retryPolicyFromConfig returns the configured retry policy, derived from
+// RetryCount and RetryWaitTime when no policy is set
+func retryPolicyFromConfig(config *Config) *RetryPolicy {
+	if config.RetryPolicy != nil {
+		return config.RetryPolicy
+	}
+
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = config.RetryCount + 1
+	if config.RetryWaitTime > 0 {
+		policy.InitialWait = config.RetryWaitTime
+	}
+	return policy
+}
+
+// nextWait reports whether the failed attempt should be retried and how long to wait before it
+func (p *RetryPolicy) nextWait(attempt int, method string, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || !p.isRetryableMethod(method) {
+		return 0, false
+	}
+
+	if errors.Is(err, ErrRateLimited) || errors.Is(err, ErrNoAPIKeyAvailable) || errors.Is(err, ErrCircuitOpen) {
+		return 0, false
+	}
+
+	var serverWait time.Duration
+	if apiErr, ok := AsAPIError(err); ok {
+		if !p.isRetryableStatus(apiErr.StatusCode) {
+			return 0, false
+		}
+		if p.RespectRetryAfter {
+			serverWait = serverRequestedWait(apiErr)
+		}
+	} else if !p.RetryNetworkErrors {
+		return 0, false
+	}
+
+	if serverWait > 0 {
+		if p.MaxWait > 0 && serverWait > p.MaxWait {
+			return 0, false
+		}
+		return serverWait, true
+	}
+
+	return p.backoff(attempt), true
+}
+
+// backoff returns the jittered exponential wait duration after the given attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	wait := float64(p.InitialWait) * math.Pow(2, float64(attempt-1))
+	if p.MaxWait > 0 && wait > float64(p.MaxWait) {
+		wait = float64(p.MaxWait)
+	}
+
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		wait = wait*(1-jitter) + rand.Float64()*wait*jitter
+	}
+
+	return time.Duration(wait)
+}
+
+func (p *RetryPolicy) isRetryableMethod(method string) bool {
+	for _, m := range p.RetryableMethods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) isRetryableStatus(statusCode int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// serverRequestedWait returns the wait duration requested by the response headers
+func serverRequestedWait(apiErr *APIError) time.Duration {
+	if apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+	if apiErr.Header == nil {
+		return 0
+	}
+
+	value := strings.TrimSpace(apiErr.Header.Get("X-Ratelimit-Reset"))
+	if value == "" {
+		return 0
+	}
+
+	reset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || reset <= 0 {
+		return parseRetryAfter(value, time.Now())
+	}
+
+	// Large values are Unix timestamps, small values are seconds from now
+	if reset > 1_000_000_000 {
+		if wait := time.Until(time.Unix(reset, 0)); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return time.Duration(reset) * time.Second
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return wrapContextError(ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
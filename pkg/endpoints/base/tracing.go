@@ -0,0 +1,109 @@
+package base
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation name reported by spans of this package
+const tracerName = "github.com/ipangpang/coingecko-v3"
+
+// Span attribute keys
+const (
+	AttributeOperation  = attribute.Key("coingecko.operation")
+	AttributeEndpoint   = attribute.Key("coingecko.endpoint")
+	AttributeVsCurrency = attribute.Key("coingecko.vs_currency")
+	AttributeRetryCount = attribute.Key("coingecko.retry_count")
+	AttributeCacheHit   = attribute.Key("coingecko.cache_hit")
+	AttributeErrorCode  = attribute.Key("coingecko.error_code")
+	AttributeMethod     = attribute.Key("http.request.method")
+	AttributeStatusCode = attribute.Key("http.response.status_code")
+	AttributeBodySize   = attribute.Key("http.response.body.size")
+)
+
+// WithTracerProvider creates an OpenTelemetry span for every call using tp
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return func(c *Config) {
+		c.TracerProvider = tp
+	}
+}
+
+// tracingMiddleware creates a span per logical call, child of the span carried by the caller's context
+func tracingMiddleware(tracer trace.Tracer) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (*Response, error) {
+			ctx, span := tracer.Start(ctx, spanName(call), trace.WithSpanKind(trace.SpanKindClient))
+			defer span.End()
+
+			span.SetAttributes(
+				AttributeOperation.String(call.Operation),
+				AttributeEndpoint.String(call.Path),
+				AttributeMethod.String(call.Method),
+			)
+			// Path parameters carry the coin, exchange or contract identifiers
+			for name, value := range call.PathParams {
+				span.SetAttributes(attribute.String("coingecko."+name, value))
+			}
+			if vsCurrency := call.QueryParams["vs_currency"]; vsCurrency != "" {
+				span.SetAttributes(AttributeVsCurrency.String(vsCurrency))
+			}
+
+			response, err := next(ctx, call)
+			if err != nil {
+				if apiErr, ok := AsAPIError(err); ok {
+					span.SetAttributes(
+						AttributeStatusCode.Int(apiErr.StatusCode),
+						AttributeErrorCode.Int(apiErr.ErrorCode),
+					)
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return nil, err
+			}
+
+			if response != nil {
+				retries := 0
+				if response.Attempts > 1 {
+					retries = response.Attempts - 1
+				}
+				span.SetAttributes(
+					AttributeStatusCode.Int(response.StatusCode),
+					AttributeRetryCount.Int(retries),
+					AttributeCacheHit.Bool(response.FromCache),
+					AttributeBodySize.Int(len(response.Body)),
+				)
+			}
+
+			return response, nil
+		}
+	}
+}
+
+// spanName returns the span name of a call, e.g. "coingecko.coins.GetCoinMarketChartRange"
+func spanName(call *Call) string {
+	if call.Operation != "" {
+		return "coingecko." + call.Operation
+	}
+	return "coingecko " + call.Method + " " + call.Path
+}
+
+// addRetryEvent records a retry on the span carried by ctx, if any
+func addRetryEvent(ctx context.Context, attempt RetryAttempt) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attributes := []attribute.KeyValue{
+		attribute.Int("coingecko.attempt", attempt.Attempt),
+		attribute.String("coingecko.retry_wait", attempt.Wait.String()),
+		attribute.String("error", attempt.Err.Error()),
+	}
+	if attempt.StatusCode != 0 {
+		attributes = append(attributes, AttributeStatusCode.Int(attempt.StatusCode))
+	}
+	span.AddEvent("retry", trace.WithAttributes(attributes...))
+}
@@ -0,0 +1,386 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DateLayout is the layout of dates accepted by the API, dd-mm-yyyy
+const DateLayout = "02-01-2006"
+
+// SolanaPlatform is the asset platform ID of Solana
+const SolanaPlatform = "solana"
+
+var (
+	evmAddressPattern    = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	solanaAddressPattern = regexp.MustCompile(`^[1-9A-HJ-NP-Za-km-z]{32,44}$`)
+	vsCurrencyPattern    = regexp.MustCompile(`^[a-z0-9]+$`)
+)
+
+// defaultEVMPlatforms are the IDs of the asset platforms using EVM (0x...) addresses
+var defaultEVMPlatforms = []string{
+	"ethereum", "binance-smart-chain", "polygon-pos", "polygon-zkevm", "arbitrum-one",
+	"arbitrum-nova", "optimistic-ethereum", "base", "avalanche", "fantom", "xdai", "cronos",
+	"zksync", "linea", "scroll", "blast", "mantle", "celo", "moonbeam", "moonriver", "aurora",
+	"metis-andromeda", "kava", "klay-token", "harmony-shard-0", "boba", "fuse", "sonic",
+	"berachain", "unichain",
+}
+
+var (
+	evmPlatformsMu sync.RWMutex
+	evmPlatforms   = make(map[string]struct{})
+)
+
+// RegisterEVMPlatforms adds asset platforms whose contract addresses are checked
+// as EVM (0x...) addresses by the address validation rule
+func RegisterEVMPlatforms(platforms ...string) {
+	evmPlatformsMu.Lock()
+	defer evmPlatformsMu.Unlock()
+
+	for _, platform := range platforms {
+		evmPlatforms[strings.ToLower(platform)] = struct{}{}
+	}
+}
+
+// IsEVMPlatform reports whether platform is a known EVM asset platform
+func IsEVMPlatform(platform string) bool {
+	evmPlatformsMu.RLock()
+	defer evmPlatformsMu.RUnlock()
+
+	_, ok := evmPlatforms[strings.ToLower(platform)]
+	return ok
+}
+
+// IsContractAddress reports whether address is a valid contract address on platform.
+// Addresses on EVM platforms and Solana are checked, addresses on other platforms are accepted.
+func IsContractAddress(platform, address string) bool {
+	switch {
+	case address == "":
+		return false
+	case IsEVMPlatform(platform):
+		return evmAddressPattern.MatchString(address)
+	case strings.EqualFold(platform, SolanaPlatform):
+		return solanaAddressPattern.MatchString(address)
+	}
+	return true
+}
+
+// defaultVsCurrencies are the currencies returned by /simple/supported_vs_currencies
+var defaultVsCurrencies = []string{
+	"btc", "eth", "ltc", "bch", "bnb", "eos", "xrp", "xlm", "link", "dot", "yfi", "sol",
+	"usd", "aed", "ars", "aud", "bdt", "bhd", "bmd", "brl", "cad", "chf", "clp", "cny",
+	"czk", "dkk", "eur", "gbp", "gel", "hkd", "huf", "idr", "ils", "inr", "jpy", "krw",
+	"kwd", "lkr", "mmk", "mxn", "myr", "ngn", "nok", "nzd", "php", "pkr", "pln", "rub",
+	"sar", "sek", "sgd", "thb", "try", "twd", "uah", "vef", "vnd", "zar", "xdr", "xag",
+	"xau", "bits", "sats",
+}
+
+var (
+	vsCurrenciesMu           sync.RWMutex
+	vsCurrencies             = make(map[string]struct{})
+	requireKnownVsCurrencies atomic.Bool
+)
+
+func init() {
+	RegisterEVMPlatforms(defaultEVMPlatforms...)
+	RegisterVsCurrencies(defaultVsCurrencies...)
+}
+
+// RequireKnownVsCurrencies makes the vs_currency validation rule reject currencies
+// not registered with RegisterVsCurrencies. By default any lowercase currency code is accepted,
+// so currencies added to the API after this release keep working.
+func RequireKnownVsCurrencies(require bool) {
+	requireKnownVsCurrencies.Store(require)
+}
+
+// RegisterVsCurrencies adds known vs currencies, e.g. currencies added to the API after this release
+// or the result of /simple/supported_vs_currencies
+func RegisterVsCurrencies(currencies ...string) {
+	vsCurrenciesMu.Lock()
+	defer vsCurrenciesMu.Unlock()
+
+	for _, currency := range currencies {
+		vsCurrencies[strings.ToLower(currency)] = struct{}{}
+	}
+}
+
+// IsVsCurrency reports whether currency is a known vs currency
+func IsVsCurrency(currency string) bool {
+	vsCurrenciesMu.RLock()
+	defer vsCurrenciesMu.RUnlock()
+
+	_, ok := vsCurrencies[strings.ToLower(currency)]
+	return ok
+}
+
+// FieldError describes a request field failing validation
+type FieldError struct {
+	// Field is the JSON name of the field, e.g. "vs_currency" or "ids[2]"
+	Field string
+	// Rule is the failed validation rule, e.g. "required", "oneof" or "days"
+	Rule string
+	// Param is the parameter of the rule, e.g. "250" for "max=250"
+	Param string
+	// Value is the invalid value
+	Value interface{}
+	// Message is a human readable description of the failure
+	Message string
+}
+
+// Error implements the error interface
+func (e FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// ValidationError is returned when a request fails validation
+type ValidationError struct {
+	// Request is the type name of the request, e.g. "GetCoinDataByIDRequest"
+	Request string
+	// Fields lists every failing field
+	Fields []FieldError
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// AsValidationError returns the ValidationError wrapped in err, if any
+func AsValidationError(err error) (*ValidationError, bool) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr, true
+	}
+	return nil, false
+}
+
+// IsValidationError reports whether err is caused by an invalid request
+func IsValidationError(err error) bool {
+	_, ok := AsValidationError(err)
+	return ok
+}
+
+var (
+	validatorOnce     sync.Once
+	validatorInstance *validator.Validate
+)
+
+// Validator returns the validator shared by every request, with the CoinGecko rules registered:
+//
+//   - days: a positive number of days or "max"
+//   - date: a date in dd-mm-yyyy format
+//   - address=Field: a contract address on the asset platform held by Field, see IsContractAddress
+//   - vs_currency: a lowercase currency code, or a known vs currency with RequireKnownVsCurrencies
+//
+// Ranges are checked with the built-in ltfield rule, e.g. `validate:"required,ltfield=To"`.
+func Validator() *validator.Validate {
+	validatorOnce.Do(func() {
+		v := validator.New()
+
+		// Report fields by the name the API knows them by
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				return field.Name
+			}
+			return name
+		})
+
+		mustRegister(v, "days", validateDays)
+		mustRegister(v, "date", validateDate)
+		mustRegister(v, "address", validateAddress)
+		mustRegister(v, "vs_currency", validateVsCurrency)
+
+		validatorInstance = v
+	})
+	return validatorInstance
+}
+
+// mustRegister registers a validation rule, panicking on invalid tags
+func mustRegister(v *validator.Validate, tag string, fn validator.Func) {
+	if err := v.RegisterValidation(tag, fn); err != nil {
+		panic(fmt.Sprintf("failed to register validation %q: %v", tag, err))
+	}
+}
+
+// Validate checks request against its `validate` struct tags,
+// returning a *ValidationError listing every failing field
+func Validate(request interface{}) error {
+	err := Validator().Struct(request)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	validationErr := &ValidationError{
+		Request: requestTypeName(request),
+		Fields:  make([]FieldError, len(fieldErrs)),
+	}
+	for i, fe := range fieldErrs {
+		validationErr.Fields[i] = FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Value:   fe.Value(),
+			Message: validationMessage(fe),
+		}
+	}
+	return validationErr
+}
+
+// requestTypeName returns the type name of request
+func requestTypeName(request interface{}) string {
+	t := reflect.TypeOf(request)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name()
+}
+
+// validationMessage returns a human readable description of a failed rule
+func validationMessage(fe validator.FieldError) string {
+	param := fe.Param()
+	isCollection := false
+	switch fe.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		isCollection = true
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "min", "gte":
+		if isCollection {
+			return "must contain at least " + param + " item(s)"
+		}
+		if fe.Kind() == reflect.String {
+			return "must be at least " + param + " character(s) long"
+		}
+		return "must be at least " + param
+	case "max", "lte":
+		if isCollection {
+			return "must contain at most " + param + " item(s)"
+		}
+		if fe.Kind() == reflect.String {
+			return "must be at most " + param + " character(s) long"
+		}
+		return "must be at most " + param
+	case "ltfield":
+		return "must be less than " + snakeCase(param)
+	case "days":
+		return `must be a positive number of days or "max"`
+	case "date":
+		return "must be a date in dd-mm-yyyy format"
+	case "address":
+		if param == "" {
+			return "must be a contract address"
+		}
+		return "must be a contract address on the platform of " + snakeCase(param)
+	case "vs_currency":
+		if value, ok := fe.Value().(string); ok && vsCurrencyPattern.MatchString(value) {
+			return "must be a supported vs currency, such as usd"
+		}
+		return "must be a lowercase currency code, such as usd"
+	}
+
+	return "failed the " + fe.Tag() + " validation"
+}
+
+// snakeCase returns the JSON name of a request field from its Go name,
+// following the convention of every request type, e.g. "VsCurrency" becomes "vs_currency"
+// and "AssetPlatformID" becomes "asset_platform_id"
+func snakeCase(name string) string {
+	isUpper := func(b byte) bool { return b >= 'A' && b <= 'Z' }
+
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if isUpper(c) {
+			// Start a word unless c continues an initialism such as "ID"
+			if i > 0 && (!isUpper(name[i-1]) || i+1 < len(name) && !isUpper(name[i+1])) {
+				b.WriteByte('_')
+			}
+			c += 'a' - 'A'
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// validateDays accepts a positive number of days or "max"
+func validateDays(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		value := field.String()
+		if value == "max" {
+			return true
+		}
+		days, err := strconv.Atoi(value)
+		return err == nil && days > 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() > 0
+	}
+	return false
+}
+
+// validateDate accepts a date in dd-mm-yyyy format
+func validateDate(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	_, err := time.Parse(DateLayout, field.String())
+	return err == nil
+}
+
+// validateAddress accepts a contract address on the asset platform held by the field named by the rule parameter
+func validateAddress(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+
+	platform := ""
+	if name := fl.Param(); name != "" {
+		parent := reflect.Indirect(fl.Parent())
+		if parent.Kind() == reflect.Struct {
+			if platformField := parent.FieldByName(name); platformField.Kind() == reflect.String {
+				platform = platformField.String()
+			}
+		}
+	}
+	return IsContractAddress(platform, field.String())
+}
+
+// validateVsCurrency accepts a lowercase currency code, restricted to known vs currencies
+// when RequireKnownVsCurrencies is set
+func validateVsCurrency(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String || !vsCurrencyPattern.MatchString(field.String()) {
+		return false
+	}
+	return !requireKnownVsCurrencies.Load() || IsVsCurrency(field.String())
+}
@@ -0,0 +1,206 @@
+package base
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	testEVMAddress    = "0xdac17f958d2ee523a2206206994597c13d831ec7"
+	testSolanaAddress = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+)
+
+// addressRequest holds a single contract address
+type addressRequest struct {
+	AssetPlatformID string `json:"asset_platform_id"`
+	ContractAddress string `json:"contract_address" validate:"required,address=AssetPlatformID"`
+}
+
+// addressesRequest holds a list of contract addresses
+type addressesRequest struct {
+	ID                string   `json:"id"`
+	ContractAddresses []string `json:"contract_addresses" validate:"required,min=1,dive,address=ID"`
+}
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		platform string
+		address  string
+		valid    bool
+	}{
+		{platform: "ethereum", address: testEVMAddress, valid: true},
+		{platform: "Ethereum", address: strings.ToUpper(testEVMAddress), valid: false},
+		{platform: "Ethereum", address: "0x" + strings.ToUpper(testEVMAddress[2:]), valid: true},
+		{platform: "binance-smart-chain", address: testEVMAddress, valid: true},
+		{platform: "ethereum", address: testSolanaAddress, valid: false},
+		{platform: "ethereum", address: "0x1234", valid: false},
+		{platform: "solana", address: testSolanaAddress, valid: true},
+		{platform: "solana", address: testEVMAddress, valid: false},
+		{platform: "tron", address: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", valid: true},
+		{platform: "near-protocol", address: "usdt.tether-token.near", valid: true},
+		{platform: "", address: "anything", valid: true},
+	}
+
+	for _, tt := range tests {
+		err := Validate(&addressRequest{AssetPlatformID: tt.platform, ContractAddress: tt.address})
+		if (err == nil) != tt.valid {
+			t.Errorf("platform %q, address %q: error = %v, want valid %v", tt.platform, tt.address, err, tt.valid)
+		}
+	}
+}
+
+func TestValidateAddressList(t *testing.T) {
+	err := Validate(&addressesRequest{ID: "ethereum", ContractAddresses: []string{testEVMAddress, testSolanaAddress}})
+	validationErr, ok := AsValidationError(err)
+	if !ok || len(validationErr.Fields) != 1 {
+		t.Fatalf("error = %v, want a single invalid address", err)
+	}
+	field := validationErr.Fields[0]
+	if field.Field != "contract_addresses[1]" || field.Message != "must be a contract address on the platform of id" {
+		t.Errorf("field error = %+v", field)
+	}
+
+	if err := Validate(&addressesRequest{ID: "solana", ContractAddresses: []string{testSolanaAddress}}); err != nil {
+		t.Errorf("Solana address list: %v", err)
+	}
+}
+
+func TestRegisterEVMPlatforms(t *testing.T) {
+	if IsContractAddress("test-evm-chain", testSolanaAddress) != true {
+		t.Fatal("address on an unknown platform rejected")
+	}
+
+	RegisterEVMPlatforms("test-evm-chain")
+	if IsContractAddress("test-evm-chain", testSolanaAddress) {
+		t.Error("non-EVM address accepted on a registered EVM platform")
+	}
+	if !IsContractAddress("test-evm-chain", testEVMAddress) {
+		t.Error("EVM address rejected on a registered EVM platform")
+	}
+}
+
+func TestValidateVsCurrency(t *testing.T) {
+	type request struct {
+		VsCurrency string `json:"vs_currency" validate:"required,vs_currency"`
+	}
+
+	tests := []struct {
+		currency    string
+		strict      bool
+		wantMessage string
+	}{
+		{currency: "usd"},
+		{currency: "newcoin"},
+		{currency: "usd", strict: true},
+		{currency: "newcoin", strict: true, wantMessage: "must be a supported vs currency, such as usd"},
+		{currency: "USD", wantMessage: "must be a lowercase currency code, such as usd"},
+		{currency: "us-d", wantMessage: "must be a lowercase currency code, such as usd"},
+	}
+
+	for _, tt := range tests {
+		RequireKnownVsCurrencies(tt.strict)
+		err := Validate(&request{VsCurrency: tt.currency})
+		RequireKnownVsCurrencies(false)
+
+		message := ""
+		if validationErr, ok := AsValidationError(err); ok {
+			message = validationErr.Fields[0].Message
+		} else if err != nil {
+			t.Fatalf("%q: unexpected error %v", tt.currency, err)
+		}
+		if message != tt.wantMessage {
+			t.Errorf("%q (strict %v): message %q, want %q", tt.currency, tt.strict, message, tt.wantMessage)
+		}
+	}
+
+	RegisterVsCurrencies("NewCoin")
+	RequireKnownVsCurrencies(true)
+	defer RequireKnownVsCurrencies(false)
+	if err := Validate(&request{VsCurrency: "newcoin"}); err != nil {
+		t.Errorf("registered currency rejected: %v", err)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"To":              "to",
+		"VsCurrency":      "vs_currency",
+		"ID":              "id",
+		"AssetPlatformID": "asset_platform_id",
+	}
+	for name, want := range tests {
+		if got := snakeCase(name); got != want {
+			t.Errorf("snakeCase(%s) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestValidateDays(t *testing.T) {
+	type stringRequest struct {
+		Days string `json:"days" validate:"required,days"`
+	}
+	type intRequest struct {
+		Days int `json:"days" validate:"days"`
+	}
+
+	tests := []struct {
+		request interface{}
+		valid   bool
+	}{
+		{request: &stringRequest{Days: "1"}, valid: true},
+		{request: &stringRequest{Days: "365"}, valid: true},
+		{request: &stringRequest{Days: "max"}, valid: true},
+		{request: &stringRequest{Days: "0"}, valid: false},
+		{request: &stringRequest{Days: "-7"}, valid: false},
+		{request: &stringRequest{Days: "MAX"}, valid: false},
+		{request: &stringRequest{Days: "1.5"}, valid: false},
+		{request: &intRequest{Days: 30}, valid: true},
+		{request: &intRequest{Days: 0}, valid: false},
+		{request: &intRequest{Days: -1}, valid: false},
+	}
+
+	for _, tt := range tests {
+		err := Validate(tt.request)
+		if (err == nil) != tt.valid {
+			t.Errorf("%+v: error = %v, want valid %v", tt.request, err, tt.valid)
+		}
+		if validationErr, ok := AsValidationError(err); ok {
+			if got := validationErr.Fields[0].Message; got != `must be a positive number of days or "max"` {
+				t.Errorf("%+v: message %q", tt.request, got)
+			}
+		}
+	}
+}
+
+func TestValidateDate(t *testing.T) {
+	type request struct {
+		Date string `json:"date" validate:"required,date"`
+	}
+
+	tests := []struct {
+		date  string
+		valid bool
+	}{
+		{date: "30-12-2023", valid: true},
+		{date: "01-01-2024", valid: true},
+		{date: "29-02-2024", valid: true},
+		{date: "29-02-2023", valid: false},
+		{date: "2023-12-30", valid: false},
+		{date: "12-30-2023", valid: false},
+		{date: "1-1-2024", valid: false},
+		{date: "30/12/2023", valid: false},
+	}
+
+	for _, tt := range tests {
+		err := Validate(&request{Date: tt.date})
+		if (err == nil) != tt.valid {
+			t.Errorf("%q: error = %v, want valid %v", tt.date, err, tt.valid)
+		}
+		if validationErr, ok := AsValidationError(err); ok {
+			field := validationErr.Fields[0]
+			if field.Field != "date" || field.Rule != "date" || field.Message != "must be a date in dd-mm-yyyy format" {
+				t.Errorf("%q: field error %+v", tt.date, field)
+			}
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package categories
 
 import (
+	"context"
 	"fmt"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"time"
 )
 
 const (
@@ -10,9 +12,16 @@ const (
 	GetCategoriesDataRequestPoint = "/coins/categories"
 )
 
+// Default cache TTLs of endpoints whose data rarely changes
+func init() {
+	base.RegisterCacheTTL(GetCategoriesListRequestPoint, 24*time.Hour)
+}
+
 type Client interface {
 	GetCategoriesList() (*GetCategoriesListResponse, error)
+	GetCategoriesListWithContext(ctx context.Context) (*GetCategoriesListResponse, error)
 	GetCategoriesData(request *GetCategoriesDataRequest) (*GetCategoriesDataResponse, error)
+	GetCategoriesDataWithContext(ctx context.Context, request *GetCategoriesDataRequest) (*GetCategoriesDataResponse, error)
 }
 
 type ClientImpl struct {
@@ -26,9 +35,17 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) GetCategoriesList() (*GetCategoriesListResponse, error) {
+	return c.GetCategoriesListWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetCategoriesListWithContext(ctx context.Context) (*GetCategoriesListResponse, error) {
 	var response GetCategoriesListResponse
 
-	if err := c.baseClient.Get(GetCategoriesListRequestPoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "categories.GetCategoriesList",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetCategoriesListRequestPoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -36,23 +53,27 @@ func (c *ClientImpl) GetCategoriesList() (*GetCategoriesListResponse, error) {
 }
 
 func (c *ClientImpl) GetCategoriesData(request *GetCategoriesDataRequest) (*GetCategoriesDataResponse, error) {
+	return c.GetCategoriesDataWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCategoriesDataWithContext(ctx context.Context, request *GetCategoriesDataRequest) (*GetCategoriesDataResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCategoriesDataResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"order":                   request.Order,
-			"per_page":                fmt.Sprintf("%d", request.PerPage),
-			"page":                    fmt.Sprintf("%d", request.Page),
-			"sparkline":               fmt.Sprintf("%v", request.Sparkline),
-			"price_change_percentage": request.PriceChangePercentage,
-		},
+		Operation:   "categories.GetCategoriesData",
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetCategoriesDataRequestPoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCategoriesDataRequestPoint, opts, &response); err != nil {
 		return nil, err
 	}
 
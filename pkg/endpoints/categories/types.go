@@ -1,6 +1,6 @@
 package categories
 
-import "github.com/go-playground/validator/v10"
+import "github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 
 // Category represents a single category
 type Category struct {
@@ -30,15 +30,15 @@ type GetCategoriesListResponse []Category
 // GetCategoriesDataRequest represents the request parameters for getting categories data
 type GetCategoriesDataRequest struct {
 	// Order is the order to sort by
-	Order string `json:"order,omitempty" validate:"omitempty,oneof=market_cap_desc market_cap_asc name_desc name_asc market_cap_change_24h_desc market_cap_change_24h_asc"`
+	Order string `json:"order,omitempty" query:"order" validate:"omitempty,oneof=market_cap_desc market_cap_asc name_desc name_asc market_cap_change_24h_desc market_cap_change_24h_asc"`
 	// PerPage is the number of results per page
-	PerPage int `json:"per_page,omitempty" validate:"omitempty,min=1,max=250"`
+	PerPage int `json:"per_page,omitempty" query:"per_page" validate:"omitempty,min=1,max=250"`
 	// Page is the page number
-	Page int `json:"page,omitempty" validate:"omitempty,min=1"`
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1"`
 	// Sparkline is whether to include sparkline data
-	Sparkline bool `json:"sparkline,omitempty"`
+	Sparkline bool `json:"sparkline,omitempty" query:"sparkline"`
 	// PriceChangePercentage is the price change percentage to filter by
-	PriceChangePercentage string `json:"price_change_percentage,omitempty" validate:"omitempty,oneof=1h 24h 7d 14d 30d 200d 1y"`
+	PriceChangePercentage string `json:"price_change_percentage,omitempty" query:"price_change_percentage" validate:"omitempty,oneof=1h 24h 7d 14d 30d 200d 1y"`
 }
 
 // GetCategoriesDataResponse represents the response from the Categories Data API
@@ -49,15 +49,15 @@ type GetCategoryDataRequest struct {
 	// CategoryID is the unique identifier of the category
 	CategoryID string `json:"category_id" validate:"required"`
 	// Order is the order to sort by
-	Order string `json:"order,omitempty" validate:"omitempty,oneof=market_cap_desc market_cap_asc name_desc name_asc market_cap_change_24h_desc market_cap_change_24h_asc"`
+	Order string `json:"order,omitempty" query:"order" validate:"omitempty,oneof=market_cap_desc market_cap_asc name_desc name_asc market_cap_change_24h_desc market_cap_change_24h_asc"`
 	// PerPage is the number of results per page
-	PerPage int `json:"per_page,omitempty" validate:"omitempty,min=1,max=250"`
+	PerPage int `json:"per_page,omitempty" query:"per_page" validate:"omitempty,min=1,max=250"`
 	// Page is the page number
-	Page int `json:"page,omitempty" validate:"omitempty,min=1"`
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1"`
 	// Sparkline is whether to include sparkline data
-	Sparkline bool `json:"sparkline,omitempty"`
+	Sparkline bool `json:"sparkline,omitempty" query:"sparkline"`
 	// PriceChangePercentage is the price change percentage to filter by
-	PriceChangePercentage string `json:"price_change_percentage,omitempty" validate:"omitempty,oneof=1h 24h 7d 14d 30d 200d 1y"`
+	PriceChangePercentage string `json:"price_change_percentage,omitempty" query:"price_change_percentage" validate:"omitempty,oneof=1h 24h 7d 14d 30d 200d 1y"`
 }
 
 // GetCategoryDataResponse represents the response from the Category Data API
@@ -116,6 +116,5 @@ type Sparkline struct {
 
 // Validate validates the request parameters
 func (r *GetCategoriesDataRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
@@ -1,9 +1,10 @@
 package coins
 
 import (
+	"context"
 	"fmt"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
-	"strings"
+	"time"
 )
 
 const (
@@ -24,22 +25,42 @@ const (
 	GetCoinTotalSupplyChartRangeEndpoint       = "/coins/{id}/total_supply_chart/range"
 )
 
+// Default cache TTLs of endpoints whose data rarely changes
+func init() {
+	base.RegisterCacheTTL(GetCoinsListEndpoint, 24*time.Hour)
+}
+
 type Client interface {
 	GetCoinsList(request *GetCoinsListRequest) (*GetCoinsListResponse, error)
+	GetCoinsListWithContext(ctx context.Context, request *GetCoinsListRequest) (*GetCoinsListResponse, error)
 	GetTopGainersAndLosers(request *GetTopGainersAndLosersRequest) (*GetTopGainersAndLosersResponse, error)
+	GetTopGainersAndLosersWithContext(ctx context.Context, request *GetTopGainersAndLosersRequest) (*GetTopGainersAndLosersResponse, error)
 	GetRecentlyAddedCoins() (*GetRecentlyAddedCoinsResponse, error)
+	GetRecentlyAddedCoinsWithContext(ctx context.Context) (*GetRecentlyAddedCoinsResponse, error)
 	GetCoinsListWithMarketData(request *GetCoinsListWithMarketDataRequest) (*GetCoinsListWithMarketDataResponse, error)
+	GetCoinsListWithMarketDataWithContext(ctx context.Context, request *GetCoinsListWithMarketDataRequest) (*GetCoinsListWithMarketDataResponse, error)
 	GetCoinDataByID(request *GetCoinDataByIDRequest) (*GetCoinDataByIDResponse, error)
+	GetCoinDataByIDWithContext(ctx context.Context, request *GetCoinDataByIDRequest) (*GetCoinDataByIDResponse, error)
 	GetCoinTickersByID(request *GetCoinTickersByIDRequest) (*GetCoinTickersByIDResponse, error)
+	GetCoinTickersByIDWithContext(ctx context.Context, request *GetCoinTickersByIDRequest) (*GetCoinTickersByIDResponse, error)
 	GetCoinHistoryByID(request *GetCoinHistoryByIDRequest) (*GetCoinHistoryByIDResponse, error)
+	GetCoinHistoryByIDWithContext(ctx context.Context, request *GetCoinHistoryByIDRequest) (*GetCoinHistoryByIDResponse, error)
 	GetCoinMarketChartByID(request *GetCoinMarketChartByIDRequest) (*GetCoinMarketChartByIDResponse, error)
+	GetCoinMarketChartByIDWithContext(ctx context.Context, request *GetCoinMarketChartByIDRequest) (*GetCoinMarketChartByIDResponse, error)
 	GetCoinMarketChartRange(request *GetCoinMarketChartRangeRequest) (*GetCoinMarketChartRangeResponse, error)
+	GetCoinMarketChartRangeWithContext(ctx context.Context, request *GetCoinMarketChartRangeRequest) (*GetCoinMarketChartRangeResponse, error)
 	GetCoinOHLCByID(request *GetCoinOHLCByIDRequest) (*GetCoinOHLCByIDResponse, error)
+	GetCoinOHLCByIDWithContext(ctx context.Context, request *GetCoinOHLCByIDRequest) (*GetCoinOHLCByIDResponse, error)
 	GetCoinOHLCRange(request *GetCoinOHLCRangeRequest) (*GetCoinOHLCRangeResponse, error)
+	GetCoinOHLCRangeWithContext(ctx context.Context, request *GetCoinOHLCRangeRequest) (*GetCoinOHLCRangeResponse, error)
 	GetCoinCirculatingSupplyChart(request *GetCoinCirculatingSupplyChartRequest) (*GetCoinCirculatingSupplyChartResponse, error)
+	GetCoinCirculatingSupplyChartWithContext(ctx context.Context, request *GetCoinCirculatingSupplyChartRequest) (*GetCoinCirculatingSupplyChartResponse, error)
 	GetCoinCirculatingSupplyChartRange(request *GetCoinCirculatingSupplyChartRangeRequest) (*GetCoinCirculatingSupplyChartRangeResponse, error)
+	GetCoinCirculatingSupplyChartRangeWithContext(ctx context.Context, request *GetCoinCirculatingSupplyChartRangeRequest) (*GetCoinCirculatingSupplyChartRangeResponse, error)
 	GetCoinTotalSupplyChart(request *GetCoinTotalSupplyChartRequest) (*GetCoinTotalSupplyChartResponse, error)
+	GetCoinTotalSupplyChartWithContext(ctx context.Context, request *GetCoinTotalSupplyChartRequest) (*GetCoinTotalSupplyChartResponse, error)
 	GetCoinTotalSupplyChartRange(request *GetCoinTotalSupplyChartRangeRequest) (*GetCoinTotalSupplyChartRangeResponse, error)
+	GetCoinTotalSupplyChartRangeWithContext(ctx context.Context, request *GetCoinTotalSupplyChartRangeRequest) (*GetCoinTotalSupplyChartRangeResponse, error)
 }
 
 type ClientImpl struct {
@@ -53,16 +74,23 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) GetCoinsList(request *GetCoinsListRequest) (*GetCoinsListResponse, error) {
+	return c.GetCoinsListWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinsListWithContext(ctx context.Context, request *GetCoinsListRequest) (*GetCoinsListResponse, error) {
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinsListResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"include_platform": fmt.Sprintf("%v", request.IncludePlatform),
-			"status":           request.Status,
-		},
+		Operation:   "coins.GetCoinsList",
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetCoinsListEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinsListEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -70,21 +98,27 @@ func (c *ClientImpl) GetCoinsList(request *GetCoinsListRequest) (*GetCoinsListRe
 }
 
 func (c *ClientImpl) GetTopGainersAndLosers(request *GetTopGainersAndLosersRequest) (*GetTopGainersAndLosersResponse, error) {
+	return c.GetTopGainersAndLosersWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetTopGainersAndLosersWithContext(ctx context.Context, request *GetTopGainersAndLosersRequest) (*GetTopGainersAndLosersResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetTopGainersAndLosersResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"vs_currency": request.VsCurrency,
-			"duration":    request.Duration,
-			"top_coins":   fmt.Sprintf("%d", request.TopCoins),
-		},
+		Operation:   "coins.GetTopGainersAndLosers",
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetTopGainersAndLosersEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetTopGainersAndLosersEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -92,9 +126,17 @@ func (c *ClientImpl) GetTopGainersAndLosers(request *GetTopGainersAndLosersReque
 }
 
 func (c *ClientImpl) GetRecentlyAddedCoins() (*GetRecentlyAddedCoinsResponse, error) {
+	return c.GetRecentlyAddedCoinsWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetRecentlyAddedCoinsWithContext(ctx context.Context) (*GetRecentlyAddedCoinsResponse, error) {
 	var response GetRecentlyAddedCoinsResponse
 
-	if err := c.baseClient.Get(GetRecentlyAddedCoinsEndpoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "coins.GetRecentlyAddedCoins",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetRecentlyAddedCoinsEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -102,27 +144,27 @@ func (c *ClientImpl) GetRecentlyAddedCoins() (*GetRecentlyAddedCoinsResponse, er
 }
 
 func (c *ClientImpl) GetCoinsListWithMarketData(request *GetCoinsListWithMarketDataRequest) (*GetCoinsListWithMarketDataResponse, error) {
+	return c.GetCoinsListWithMarketDataWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinsListWithMarketDataWithContext(ctx context.Context, request *GetCoinsListWithMarketDataRequest) (*GetCoinsListWithMarketDataResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinsListWithMarketDataResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"vs_currency":             request.VsCurrency,
-			"ids":                     strings.Join(request.IDs, ","),
-			"category":                request.Category,
-			"order":                   request.Order,
-			"per_page":                fmt.Sprintf("%d", request.PerPage),
-			"page":                    fmt.Sprintf("%d", request.Page),
-			"sparkline":               fmt.Sprintf("%v", request.Sparkline),
-			"price_change_percentage": strings.Join(request.PriceChangePercentage, ","),
-			"locale":                  request.Locale,
-		},
+		Operation:   "coins.GetCoinsListWithMarketData",
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetCoinsListWithMarketDataEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinsListWithMarketDataEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -130,28 +172,30 @@ func (c *ClientImpl) GetCoinsListWithMarketData(request *GetCoinsListWithMarketD
 }
 
 func (c *ClientImpl) GetCoinDataByID(request *GetCoinDataByIDRequest) (*GetCoinDataByIDResponse, error) {
+	return c.GetCoinDataByIDWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinDataByIDWithContext(ctx context.Context, request *GetCoinDataByIDRequest) (*GetCoinDataByIDResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinDataByIDResponse
 
 	opts := &base.RequestOptions{
+		Operation: "coins.GetCoinDataByID",
 		PathParams: map[string]string{
 			"id": request.ID,
 		},
-		QueryParams: map[string]string{
-			"localization":   fmt.Sprintf("%v", request.Localization),
-			"tickers":        fmt.Sprintf("%v", request.Tickers),
-			"market_data":    fmt.Sprintf("%v", request.MarketData),
-			"community_data": fmt.Sprintf("%v", request.CommunityData),
-			"developer_data": fmt.Sprintf("%v", request.DeveloperData),
-			"sparkline":      fmt.Sprintf("%v", request.Sparkline),
-			"locale":         request.Locale,
-		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetCoinDataByIDEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinDataByIDEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -159,22 +203,30 @@ func (c *ClientImpl) GetCoinDataByID(request *GetCoinDataByIDRequest) (*GetCoinD
 }
 
 func (c *ClientImpl) GetCoinTickersByID(request *GetCoinTickersByIDRequest) (*GetCoinTickersByIDResponse, error) {
+	return c.GetCoinTickersByIDWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinTickersByIDWithContext(ctx context.Context, request *GetCoinTickersByIDRequest) (*GetCoinTickersByIDResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinTickersByIDResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"exchange_ids": strings.Join(request.ExchangeIDs, ","),
-			"include":      strings.Join(request.Include, ","),
-			"order":        request.Order,
-			"depth":        fmt.Sprintf("%v", request.Depth),
+		Operation: "coins.GetCoinTickersByID",
+		PathParams: map[string]string{
+			"id": request.ID,
 		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(strings.Replace(GetCoinTickersByIDEndpoint, "{id}", request.ID, -1), opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinTickersByIDEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -182,20 +234,30 @@ func (c *ClientImpl) GetCoinTickersByID(request *GetCoinTickersByIDRequest) (*Ge
 }
 
 func (c *ClientImpl) GetCoinHistoryByID(request *GetCoinHistoryByIDRequest) (*GetCoinHistoryByIDResponse, error) {
+	return c.GetCoinHistoryByIDWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinHistoryByIDWithContext(ctx context.Context, request *GetCoinHistoryByIDRequest) (*GetCoinHistoryByIDResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinHistoryByIDResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"date":         request.Date,
-			"localization": fmt.Sprintf("%v", request.Localization),
+		Operation: "coins.GetCoinHistoryByID",
+		PathParams: map[string]string{
+			"id": request.ID,
 		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(strings.Replace(GetCoinHistoryByIDEndpoint, "{id}", request.ID, -1), opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinHistoryByIDEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -203,21 +265,30 @@ func (c *ClientImpl) GetCoinHistoryByID(request *GetCoinHistoryByIDRequest) (*Ge
 }
 
 func (c *ClientImpl) GetCoinMarketChartByID(request *GetCoinMarketChartByIDRequest) (*GetCoinMarketChartByIDResponse, error) {
+	return c.GetCoinMarketChartByIDWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinMarketChartByIDWithContext(ctx context.Context, request *GetCoinMarketChartByIDRequest) (*GetCoinMarketChartByIDResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinMarketChartByIDResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"vs_currency": request.VsCurrency,
-			"days":        request.Days,
-			"interval":    request.Interval,
+		Operation: "coins.GetCoinMarketChartByID",
+		PathParams: map[string]string{
+			"id": request.ID,
 		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(strings.Replace(GetCoinMarketChartByIDEndpoint, "{id}", request.ID, -1), opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinMarketChartByIDEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -225,22 +296,30 @@ func (c *ClientImpl) GetCoinMarketChartByID(request *GetCoinMarketChartByIDReque
 }
 
 func (c *ClientImpl) GetCoinMarketChartRange(request *GetCoinMarketChartRangeRequest) (*GetCoinMarketChartRangeResponse, error) {
+	return c.GetCoinMarketChartRangeWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinMarketChartRangeWithContext(ctx context.Context, request *GetCoinMarketChartRangeRequest) (*GetCoinMarketChartRangeResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinMarketChartRangeResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"vs_currency": request.VsCurrency,
-			"from":        fmt.Sprintf("%d", request.From),
-			"to":          fmt.Sprintf("%d", request.To),
-			"interval":    request.Interval,
+		Operation: "coins.GetCoinMarketChartRange",
+		PathParams: map[string]string{
+			"id": request.ID,
 		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(strings.Replace(GetCoinMarketChartRangeEndpoint, "{id}", request.ID, -1), opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinMarketChartRangeEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -248,21 +327,30 @@ func (c *ClientImpl) GetCoinMarketChartRange(request *GetCoinMarketChartRangeReq
 }
 
 func (c *ClientImpl) GetCoinOHLCByID(request *GetCoinOHLCByIDRequest) (*GetCoinOHLCByIDResponse, error) {
+	return c.GetCoinOHLCByIDWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinOHLCByIDWithContext(ctx context.Context, request *GetCoinOHLCByIDRequest) (*GetCoinOHLCByIDResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinOHLCByIDResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"vs_currency": request.VsCurrency,
-			"days":        request.Days,
-			"interval":    request.Interval,
+		Operation: "coins.GetCoinOHLCByID",
+		PathParams: map[string]string{
+			"id": request.ID,
 		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(strings.Replace(GetCoinOHLCByIDEndpoint, "{id}", request.ID, -1), opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinOHLCByIDEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -270,22 +358,30 @@ func (c *ClientImpl) GetCoinOHLCByID(request *GetCoinOHLCByIDRequest) (*GetCoinO
 }
 
 func (c *ClientImpl) GetCoinOHLCRange(request *GetCoinOHLCRangeRequest) (*GetCoinOHLCRangeResponse, error) {
+	return c.GetCoinOHLCRangeWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinOHLCRangeWithContext(ctx context.Context, request *GetCoinOHLCRangeRequest) (*GetCoinOHLCRangeResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinOHLCRangeResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"vs_currency": request.VsCurrency,
-			"from":        fmt.Sprintf("%d", request.From),
-			"to":          fmt.Sprintf("%d", request.To),
-			"interval":    request.Interval,
+		Operation: "coins.GetCoinOHLCRange",
+		PathParams: map[string]string{
+			"id": request.ID,
 		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(strings.Replace(GetCoinOHLCRangeEndpoint, "{id}", request.ID, -1), opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinOHLCRangeEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -293,19 +389,30 @@ func (c *ClientImpl) GetCoinOHLCRange(request *GetCoinOHLCRangeRequest) (*GetCoi
 }
 
 func (c *ClientImpl) GetCoinCirculatingSupplyChart(request *GetCoinCirculatingSupplyChartRequest) (*GetCoinCirculatingSupplyChartResponse, error) {
+	return c.GetCoinCirculatingSupplyChartWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinCirculatingSupplyChartWithContext(ctx context.Context, request *GetCoinCirculatingSupplyChartRequest) (*GetCoinCirculatingSupplyChartResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinCirculatingSupplyChartResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"days": request.Days,
+		Operation: "coins.GetCoinCirculatingSupplyChart",
+		PathParams: map[string]string{
+			"id": request.ID,
 		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(strings.Replace(GetCoinCirculatingSupplyChartEndpoint, "{id}", request.ID, -1), opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinCirculatingSupplyChartEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -313,20 +420,30 @@ func (c *ClientImpl) GetCoinCirculatingSupplyChart(request *GetCoinCirculatingSu
 }
 
 func (c *ClientImpl) GetCoinCirculatingSupplyChartRange(request *GetCoinCirculatingSupplyChartRangeRequest) (*GetCoinCirculatingSupplyChartRangeResponse, error) {
+	return c.GetCoinCirculatingSupplyChartRangeWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinCirculatingSupplyChartRangeWithContext(ctx context.Context, request *GetCoinCirculatingSupplyChartRangeRequest) (*GetCoinCirculatingSupplyChartRangeResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinCirculatingSupplyChartRangeResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"from": fmt.Sprintf("%d", request.From),
-			"to":   fmt.Sprintf("%d", request.To),
+		Operation: "coins.GetCoinCirculatingSupplyChartRange",
+		PathParams: map[string]string{
+			"id": request.ID,
 		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(strings.Replace(GetCoinCirculatingSupplyChartRangeEndpoint, "{id}", request.ID, -1), opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinCirculatingSupplyChartRangeEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -334,19 +451,30 @@ func (c *ClientImpl) GetCoinCirculatingSupplyChartRange(request *GetCoinCirculat
 }
 
 func (c *ClientImpl) GetCoinTotalSupplyChart(request *GetCoinTotalSupplyChartRequest) (*GetCoinTotalSupplyChartResponse, error) {
+	return c.GetCoinTotalSupplyChartWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinTotalSupplyChartWithContext(ctx context.Context, request *GetCoinTotalSupplyChartRequest) (*GetCoinTotalSupplyChartResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinTotalSupplyChartResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"days": request.Days,
+		Operation: "coins.GetCoinTotalSupplyChart",
+		PathParams: map[string]string{
+			"id": request.ID,
 		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(strings.Replace(GetCoinTotalSupplyChartEndpoint, "{id}", request.ID, -1), opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinTotalSupplyChartEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -354,20 +482,30 @@ func (c *ClientImpl) GetCoinTotalSupplyChart(request *GetCoinTotalSupplyChartReq
 }
 
 func (c *ClientImpl) GetCoinTotalSupplyChartRange(request *GetCoinTotalSupplyChartRangeRequest) (*GetCoinTotalSupplyChartRangeResponse, error) {
+	return c.GetCoinTotalSupplyChartRangeWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinTotalSupplyChartRangeWithContext(ctx context.Context, request *GetCoinTotalSupplyChartRangeRequest) (*GetCoinTotalSupplyChartRangeResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinTotalSupplyChartRangeResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"from": fmt.Sprintf("%d", request.From),
-			"to":   fmt.Sprintf("%d", request.To),
+		Operation: "coins.GetCoinTotalSupplyChartRange",
+		PathParams: map[string]string{
+			"id": request.ID,
 		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(strings.Replace(GetCoinTotalSupplyChartRangeEndpoint, "{id}", request.ID, -1), opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinTotalSupplyChartRangeEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -1,13 +1,13 @@
 package coins
 
-import "github.com/go-playground/validator/v10"
+import "github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 
 // GetCoinsListRequest represents the request parameters for getting coins list
 type GetCoinsListRequest struct {
 	// IncludePlatform indicates whether to include platform contract addresses
-	IncludePlatform bool `json:"include_platform,omitempty"`
+	IncludePlatform bool `json:"include_platform,omitempty" query:"include_platform"`
 	// Status indicates whether to include inactive coins (active or inactive)
-	Status string `json:"status,omitempty" validate:"omitempty,oneof=active inactive"`
+	Status string `json:"status,omitempty" query:"status" validate:"omitempty,oneof=active inactive"`
 }
 
 // Coin represents a single coin in the list
@@ -28,11 +28,11 @@ type GetCoinsListResponse []Coin
 // GetTopGainersAndLosersRequest represents the request parameters for getting top gainers and losers
 type GetTopGainersAndLosersRequest struct {
 	// VsCurrency is the target currency for market data
-	VsCurrency string `json:"vs_currency" validate:"required"`
+	VsCurrency string `json:"vs_currency" query:"vs_currency" validate:"required,vs_currency"`
 	// Duration is the time duration for price change calculation (1h, 24h, 7d, 14d, 30d, 200d, 1y)
-	Duration string `json:"duration" validate:"required,oneof=1h 24h 7d 14d 30d 200d 1y"`
+	Duration string `json:"duration" query:"duration" validate:"required,oneof=1h 24h 7d 14d 30d 200d 1y"`
 	// TopCoins is the market cap ranking filter (300-1000)
-	TopCoins int `json:"top_coins,omitempty" validate:"omitempty,min=300,max=1000"`
+	TopCoins int `json:"top_coins,omitempty" query:"top_coins" validate:"omitempty,min=300,max=1000"`
 }
 
 // GetTopGainersAndLosersResponse represents the response from the Top Gainers & Losers API
@@ -69,46 +69,48 @@ type GetRecentlyAddedCoinsResponse []CoinMarketData
 // GetCoinsListWithMarketDataRequest represents the request parameters for getting coins list with market data
 type GetCoinsListWithMarketDataRequest struct {
 	// VsCurrency is the target currency for market data
-	VsCurrency string `json:"vs_currency" validate:"required"`
+	VsCurrency string `json:"vs_currency" query:"vs_currency" validate:"required,vs_currency"`
 	// IDs is a list of coin IDs to get data for
-	IDs []string `json:"ids,omitempty"`
+	IDs []string `json:"ids,omitempty" query:"ids"`
 	// Category is the category to filter by
-	Category string `json:"category,omitempty"`
+	Category string `json:"category,omitempty" query:"category"`
 	// Order is the order to sort the results by
-	Order string `json:"order,omitempty" validate:"omitempty,oneof=market_cap_desc market_cap_asc gecko_desc gecko_asc volume_desc volume_asc id_desc id_asc"`
+	Order string `json:"order,omitempty" query:"order" validate:"omitempty,oneof=market_cap_desc market_cap_asc gecko_desc gecko_asc volume_desc volume_asc id_desc id_asc"`
 	// PerPage is the number of results per page
-	PerPage int `json:"per_page,omitempty" validate:"omitempty,min=1,max=250"`
+	PerPage int `json:"per_page,omitempty" query:"per_page" validate:"omitempty,min=1,max=250"`
 	// Page is the page number
-	Page int `json:"page,omitempty" validate:"omitempty,min=1"`
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1"`
 	// Sparkline indicates whether to include sparkline data
-	Sparkline bool `json:"sparkline,omitempty"`
+	Sparkline bool `json:"sparkline,omitempty" query:"sparkline"`
 	// PriceChangePercentage is the price change percentage to include
-	PriceChangePercentage []string `json:"price_change_percentage,omitempty" validate:"omitempty,dive,oneof=1h 24h 7d 14d 30d 200d 1y"`
+	PriceChangePercentage []string `json:"price_change_percentage,omitempty" query:"price_change_percentage" validate:"omitempty,dive,oneof=1h 24h 7d 14d 30d 200d 1y"`
 	// Locale is the language to use for localization
-	Locale string `json:"locale,omitempty" validate:"omitempty,oneof=en de es fr it pt ru ko ja zh"`
+	Locale string `json:"locale,omitempty" query:"locale" validate:"omitempty,oneof=en de es fr it pt ru ko ja zh"`
 }
 
 // GetCoinsListWithMarketDataResponse represents the response from the Coins List with Market Data API
 type GetCoinsListWithMarketDataResponse []CoinMarketData
 
-// GetCoinDataByIDRequest represents the request parameters for getting coin data by ID
+// GetCoinDataByIDRequest represents the request parameters for getting coin data by ID.
+// The API includes localization, tickers, market, community and developer data by default,
+// so these flags are always sent and false excludes the data.
 type GetCoinDataByIDRequest struct {
 	// ID is the unique identifier of the coin
 	ID string `json:"id" validate:"required"`
 	// Localization indicates whether to include localization data
-	Localization bool `json:"localization,omitempty"`
+	Localization bool `json:"localization,omitempty" query:"localization,required"`
 	// Tickers indicates whether to include tickers data
-	Tickers bool `json:"tickers,omitempty"`
+	Tickers bool `json:"tickers,omitempty" query:"tickers,required"`
 	// MarketData indicates whether to include market data
-	MarketData bool `json:"market_data,omitempty"`
+	MarketData bool `json:"market_data,omitempty" query:"market_data,required"`
 	// CommunityData indicates whether to include community data
-	CommunityData bool `json:"community_data,omitempty"`
+	CommunityData bool `json:"community_data,omitempty" query:"community_data,required"`
 	// DeveloperData indicates whether to include developer data
-	DeveloperData bool `json:"developer_data,omitempty"`
+	DeveloperData bool `json:"developer_data,omitempty" query:"developer_data,required"`
 	// Sparkline indicates whether to include sparkline data
-	Sparkline bool `json:"sparkline,omitempty"`
+	Sparkline bool `json:"sparkline,omitempty" query:"sparkline"`
 	// Locale is the language to use for localization
-	Locale string `json:"locale,omitempty"`
+	Locale string `json:"locale,omitempty" query:"locale"`
 }
 
 // GetCoinDataByIDResponse represents the response from the Coin Data by ID API
@@ -182,13 +184,13 @@ type GetCoinTickersByIDRequest struct {
 	// ID is the coin ID
 	ID string `json:"id" validate:"required"`
 	// ExchangeIDs is a list of exchange IDs to filter by
-	ExchangeIDs []string `json:"exchange_ids,omitempty"`
+	ExchangeIDs []string `json:"exchange_ids,omitempty" query:"exchange_ids"`
 	// Include is a list of fields to include in the response
-	Include []string `json:"include,omitempty"`
+	Include []string `json:"include,omitempty" query:"include"`
 	// Order is the order to sort the results by
-	Order string `json:"order,omitempty"`
+	Order string `json:"order,omitempty" query:"order"`
 	// Depth is the depth of the order book to include
-	Depth bool `json:"depth,omitempty"`
+	Depth bool `json:"depth,omitempty" query:"depth"`
 }
 
 // GetCoinTickersByIDResponse represents the response from the Coin Tickers API
@@ -237,9 +239,9 @@ type GetCoinHistoryByIDRequest struct {
 	// ID is the coin ID
 	ID string `json:"id" validate:"required"`
 	// Date is the date to get history for (dd-mm-yyyy)
-	Date string `json:"date" validate:"required"`
-	// Localization indicates whether to include localized data
-	Localization bool `json:"localization,omitempty"`
+	Date string `json:"date" query:"date" validate:"required,date"`
+	// Localization indicates whether to include localized data, always sent as the API defaults to true
+	Localization bool `json:"localization,omitempty" query:"localization,required"`
 }
 
 // GetCoinHistoryByIDResponse represents the response from the Coin History API
@@ -259,11 +261,11 @@ type GetCoinMarketChartByIDRequest struct {
 	// ID is the coin ID
 	ID string `json:"id" validate:"required"`
 	// VsCurrency is the target currency to get prices in
-	VsCurrency string `json:"vs_currency" validate:"required"`
+	VsCurrency string `json:"vs_currency" query:"vs_currency" validate:"required,vs_currency"`
 	// Days is the number of days of data to get
-	Days string `json:"days" validate:"required"`
+	Days string `json:"days" query:"days" validate:"required,days"`
 	// Interval is the data interval (daily, hourly, minutely)
-	Interval string `json:"interval,omitempty"`
+	Interval string `json:"interval,omitempty" query:"interval"`
 }
 
 // GetCoinMarketChartByIDResponse represents the response from the Coin Market Chart API
@@ -278,13 +280,13 @@ type GetCoinMarketChartRangeRequest struct {
 	// ID is the coin ID
 	ID string `json:"id" validate:"required"`
 	// VsCurrency is the target currency to get prices in
-	VsCurrency string `json:"vs_currency" validate:"required"`
+	VsCurrency string `json:"vs_currency" query:"vs_currency" validate:"required,vs_currency"`
 	// From is the start date (Unix timestamp)
-	From int64 `json:"from" validate:"required"`
+	From int64 `json:"from" query:"from" validate:"required,ltfield=To"`
 	// To is the end date (Unix timestamp)
-	To int64 `json:"to" validate:"required"`
+	To int64 `json:"to" query:"to" validate:"required"`
 	// Interval is the data interval (daily, hourly, minutely)
-	Interval string `json:"interval,omitempty"`
+	Interval string `json:"interval,omitempty" query:"interval"`
 }
 
 // GetCoinMarketChartRangeResponse represents the response from the Coin Market Chart Range API
@@ -299,11 +301,11 @@ type GetCoinOHLCByIDRequest struct {
 	// ID is the coin ID
 	ID string `json:"id" validate:"required"`
 	// VsCurrency is the target currency to get prices in
-	VsCurrency string `json:"vs_currency" validate:"required"`
+	VsCurrency string `json:"vs_currency" query:"vs_currency" validate:"required,vs_currency"`
 	// Days is the number of days of data to get
-	Days string `json:"days" validate:"required"`
+	Days string `json:"days" query:"days" validate:"required,days"`
 	// Interval is the data interval (daily, hourly, minutely)
-	Interval string `json:"interval,omitempty"`
+	Interval string `json:"interval,omitempty" query:"interval"`
 }
 
 // GetCoinOHLCByIDResponse represents the response from the Coin OHLC API
@@ -314,13 +316,13 @@ type GetCoinOHLCRangeRequest struct {
 	// ID is the coin ID
 	ID string `json:"id" validate:"required"`
 	// VsCurrency is the target currency to get prices in
-	VsCurrency string `json:"vs_currency" validate:"required"`
+	VsCurrency string `json:"vs_currency" query:"vs_currency" validate:"required,vs_currency"`
 	// From is the start date (Unix timestamp)
-	From int64 `json:"from" validate:"required"`
+	From int64 `json:"from" query:"from" validate:"required,ltfield=To"`
 	// To is the end date (Unix timestamp)
-	To int64 `json:"to" validate:"required"`
+	To int64 `json:"to" query:"to" validate:"required"`
 	// Interval is the data interval (daily, hourly, minutely)
-	Interval string `json:"interval,omitempty"`
+	Interval string `json:"interval,omitempty" query:"interval"`
 }
 
 // GetCoinOHLCRangeResponse represents the response from the Coin OHLC Range API
@@ -331,7 +333,7 @@ type GetCoinCirculatingSupplyChartRequest struct {
 	// ID is the coin ID
 	ID string `json:"id" validate:"required"`
 	// Days is the number of days of data to get
-	Days string `json:"days" validate:"required"`
+	Days string `json:"days" query:"days" validate:"required,days"`
 }
 
 // GetCoinCirculatingSupplyChartResponse represents the response from the Coin Circulating Supply Chart API
@@ -342,9 +344,9 @@ type GetCoinCirculatingSupplyChartRangeRequest struct {
 	// ID is the coin ID
 	ID string `json:"id" validate:"required"`
 	// From is the start date (Unix timestamp)
-	From int64 `json:"from" validate:"required"`
+	From int64 `json:"from" query:"from" validate:"required,ltfield=To"`
 	// To is the end date (Unix timestamp)
-	To int64 `json:"to" validate:"required"`
+	To int64 `json:"to" query:"to" validate:"required"`
 }
 
 // GetCoinCirculatingSupplyChartRangeResponse represents the response from the Coin Circulating Supply Chart Range API
@@ -355,7 +357,7 @@ type GetCoinTotalSupplyChartRequest struct {
 	// ID is the coin ID
 	ID string `json:"id" validate:"required"`
 	// Days is the number of days of data to get
-	Days string `json:"days" validate:"required"`
+	Days string `json:"days" query:"days" validate:"required,days"`
 }
 
 // GetCoinTotalSupplyChartResponse represents the response from the Coin Total Supply Chart API
@@ -366,9 +368,9 @@ type GetCoinTotalSupplyChartRangeRequest struct {
 	// ID is the coin ID
 	ID string `json:"id" validate:"required"`
 	// From is the start date (Unix timestamp)
-	From int64 `json:"from" validate:"required"`
+	From int64 `json:"from" query:"from" validate:"required,ltfield=To"`
 	// To is the end date (Unix timestamp)
-	To int64 `json:"to" validate:"required"`
+	To int64 `json:"to" query:"to" validate:"required"`
 }
 
 // GetCoinTotalSupplyChartRangeResponse represents the response from the Coin Total Supply Chart Range API
@@ -381,8 +383,7 @@ func (r *GetCoinsListRequest) Validate() error {
 		r.Status = "active"
 	}
 
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinsListWithMarketDataRequest) Validate() error {
@@ -406,63 +407,51 @@ func (r *GetCoinsListWithMarketDataRequest) Validate() error {
 		r.Locale = "en"
 	}
 
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinDataByIDRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinTickersByIDRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinHistoryByIDRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinMarketChartByIDRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinMarketChartRangeRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinOHLCByIDRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinOHLCRangeRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinCirculatingSupplyChartRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinCirculatingSupplyChartRangeRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinTotalSupplyChartRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinTotalSupplyChartRangeRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetTopGainersAndLosersRequest) Validate() error {
@@ -476,6 +465,5 @@ func (r *GetTopGainersAndLosersRequest) Validate() error {
 		r.TopCoins = 1000
 	}
 
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
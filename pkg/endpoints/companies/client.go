@@ -1,6 +1,7 @@
 package companies
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
@@ -10,23 +11,30 @@ const (
 	GetPublicTreasuryEndpoint = "/companies/public_treasury/bitcoin"
 )
 
-type Client struct {
+type Client interface {
+	GetPublicTreasury() (*GetPublicTreasuryResponse, error)
+	GetPublicTreasuryWithContext(ctx context.Context) (*GetPublicTreasuryResponse, error)
+}
+
+type ClientImpl struct {
 	baseClient *base.BaseClient
 }
 
 func NewClient(baseClient *base.BaseClient) Client {
-	return Client{
+	return &ClientImpl{
 		baseClient: baseClient,
 	}
 }
 
-func (c *Client) GetPublicTreasury() (*GetPublicTreasuryResponse, error) {
+func (c *ClientImpl) GetPublicTreasury() (*GetPublicTreasuryResponse, error) {
+	return c.GetPublicTreasuryWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetPublicTreasuryWithContext(ctx context.Context) (*GetPublicTreasuryResponse, error) {
 	var response GetPublicTreasuryResponse
 
-	err := c.baseClient.Get(GetPublicTreasuryEndpoint, &base.RequestOptions{
-		QueryParams: map[string]string{
-			"timeout": "10s",
-		},
+	err := c.baseClient.GetWithContext(ctx, GetPublicTreasuryEndpoint, &base.RequestOptions{
+		Operation: "companies.GetPublicTreasury",
 	}, &response)
 
 	if err != nil {
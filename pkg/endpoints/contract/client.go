@@ -1,6 +1,7 @@
 package contract
 
 import (
+	"context"
 	"fmt"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 )
@@ -13,8 +14,11 @@ const (
 
 type Client interface {
 	GetContractData(request *GetContractDataRequest) (*GetContractDataResponse, error)
+	GetContractDataWithContext(ctx context.Context, request *GetContractDataRequest) (*GetContractDataResponse, error)
 	GetContractMarketChart(request *GetContractMarketChartRequest) (*GetContractMarketChartResponse, error)
+	GetContractMarketChartWithContext(ctx context.Context, request *GetContractMarketChartRequest) (*GetContractMarketChartResponse, error)
 	GetContractMarketChartRange(request *GetContractMarketChartRangeRequest) (*GetContractMarketChartRangeResponse, error)
+	GetContractMarketChartRangeWithContext(ctx context.Context, request *GetContractMarketChartRangeRequest) (*GetContractMarketChartRangeResponse, error)
 }
 
 type ClientImpl struct {
@@ -28,6 +32,10 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) GetContractData(request *GetContractDataRequest) (*GetContractDataResponse, error) {
+	return c.GetContractDataWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetContractDataWithContext(ctx context.Context, request *GetContractDataRequest) (*GetContractDataResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
@@ -35,13 +43,14 @@ func (c *ClientImpl) GetContractData(request *GetContractDataRequest) (*GetContr
 	var response GetContractDataResponse
 
 	opts := &base.RequestOptions{
+		Operation: "contract.GetContractData",
 		PathParams: map[string]string{
 			"asset_platform_id": request.AssetPlatformID,
 			"contract_address":  request.ContractAddress,
 		},
 	}
 
-	if err := c.baseClient.Get(GetContractDataEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetContractDataEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -49,24 +58,31 @@ func (c *ClientImpl) GetContractData(request *GetContractDataRequest) (*GetContr
 }
 
 func (c *ClientImpl) GetContractMarketChart(request *GetContractMarketChartRequest) (*GetContractMarketChartResponse, error) {
+	return c.GetContractMarketChartWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetContractMarketChartWithContext(ctx context.Context, request *GetContractMarketChartRequest) (*GetContractMarketChartResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetContractMarketChartResponse
 
 	opts := &base.RequestOptions{
+		Operation: "contract.GetContractMarketChart",
 		PathParams: map[string]string{
 			"asset_platform_id": request.AssetPlatformID,
 			"contract_address":  request.ContractAddress,
 		},
-		QueryParams: map[string]string{
-			"vs_currency": request.VsCurrency,
-			"days":        fmt.Sprintf("%d", request.Days),
-		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetContractMarketChartEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetContractMarketChartEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -74,25 +90,31 @@ func (c *ClientImpl) GetContractMarketChart(request *GetContractMarketChartReque
 }
 
 func (c *ClientImpl) GetContractMarketChartRange(request *GetContractMarketChartRangeRequest) (*GetContractMarketChartRangeResponse, error) {
+	return c.GetContractMarketChartRangeWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetContractMarketChartRangeWithContext(ctx context.Context, request *GetContractMarketChartRangeRequest) (*GetContractMarketChartRangeResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetContractMarketChartRangeResponse
 
 	opts := &base.RequestOptions{
+		Operation: "contract.GetContractMarketChartRange",
 		PathParams: map[string]string{
 			"asset_platform_id": request.AssetPlatformID,
 			"contract_address":  request.ContractAddress,
 		},
-		QueryParams: map[string]string{
-			"vs_currency": request.VsCurrency,
-			"from":        fmt.Sprintf("%d", request.From),
-			"to":          fmt.Sprintf("%d", request.To),
-		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetContractMarketChartRangeEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetContractMarketChartRangeEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -1,13 +1,13 @@
 package contract
 
-import "github.com/go-playground/validator/v10"
+import "github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 
 // GetContractDataRequest represents the request parameters for getting contract data
 type GetContractDataRequest struct {
 	// AssetPlatformID is the ID of the asset platform
 	AssetPlatformID string `json:"asset_platform_id" validate:"required"`
 	// ContractAddress is the contract address
-	ContractAddress string `json:"contract_address" validate:"required"`
+	ContractAddress string `json:"contract_address" validate:"required,address=AssetPlatformID"`
 }
 
 // GetContractDataResponse represents the response from the Contract Data API
@@ -77,11 +77,11 @@ type GetContractMarketChartRequest struct {
 	// AssetPlatformID is the ID of the asset platform
 	AssetPlatformID string `json:"asset_platform_id" validate:"required"`
 	// ContractAddress is the contract address
-	ContractAddress string `json:"contract_address" validate:"required"`
+	ContractAddress string `json:"contract_address" validate:"required,address=AssetPlatformID"`
 	// VsCurrency is the target currency
-	VsCurrency string `json:"vs_currency" validate:"required"`
+	VsCurrency string `json:"vs_currency" query:"vs_currency" validate:"required,vs_currency"`
 	// Days is the number of days of data to return
-	Days int `json:"days" validate:"required,min=1,max=365"`
+	Days int `json:"days" query:"days" validate:"required,min=1,max=365"`
 }
 
 // GetContractMarketChartResponse represents the response from the Contract Market Chart API
@@ -92,13 +92,13 @@ type GetContractMarketChartRangeRequest struct {
 	// AssetPlatformID is the ID of the asset platform
 	AssetPlatformID string `json:"asset_platform_id" validate:"required"`
 	// ContractAddress is the contract address
-	ContractAddress string `json:"contract_address" validate:"required"`
+	ContractAddress string `json:"contract_address" validate:"required,address=AssetPlatformID"`
 	// VsCurrency is the target currency
-	VsCurrency string `json:"vs_currency" validate:"required"`
+	VsCurrency string `json:"vs_currency" query:"vs_currency" validate:"required,vs_currency"`
 	// From is the start date in Unix timestamp
-	From int64 `json:"from" validate:"required"`
+	From int64 `json:"from" query:"from" validate:"required,ltfield=To"`
 	// To is the end date in Unix timestamp
-	To int64 `json:"to" validate:"required"`
+	To int64 `json:"to" query:"to" validate:"required"`
 }
 
 // GetContractMarketChartRangeResponse represents the response from the Contract Market Chart Range API
@@ -106,18 +106,15 @@ type GetContractMarketChartRangeResponse [][]interface{}
 
 // Validate validates the request parameters
 func (r *GetContractDataRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 // Validate validates the request parameters
 func (r *GetContractMarketChartRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 // Validate validates the request parameters
 func (r *GetContractMarketChartRangeRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
@@ -1,8 +1,10 @@
 package derivatives
 
 import (
+	"context"
 	"fmt"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"time"
 )
 
 const (
@@ -12,11 +14,20 @@ const (
 	GetDerivativesExchangesListIDEndpoint = "/derivatives/exchanges/list"
 )
 
+// Default cache TTLs of endpoints whose data rarely changes
+func init() {
+	base.RegisterCacheTTL(GetDerivativesExchangesListIDEndpoint, 24*time.Hour)
+}
+
 type Client interface {
 	GetDerivativesList() (*GetDerivativesListResponse, error)
+	GetDerivativesListWithContext(ctx context.Context) (*GetDerivativesListResponse, error)
 	GetDerivativesExchangesList() (*GetDerivativesExchangesListResponse, error)
+	GetDerivativesExchangesListWithContext(ctx context.Context) (*GetDerivativesExchangesListResponse, error)
 	GetDerivativeExchangeData(request *GetDerivativeExchangeDataRequest) (*GetDerivativeExchangeDataResponse, error)
+	GetDerivativeExchangeDataWithContext(ctx context.Context, request *GetDerivativeExchangeDataRequest) (*GetDerivativeExchangeDataResponse, error)
 	GetDerivativesExchangesListIDMap() (*GetDerivativesExchangesListIDMapResponse, error)
+	GetDerivativesExchangesListIDMapWithContext(ctx context.Context) (*GetDerivativesExchangesListIDMapResponse, error)
 }
 
 type ClientImpl struct {
@@ -30,9 +41,17 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) GetDerivativesList() (*GetDerivativesListResponse, error) {
+	return c.GetDerivativesListWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetDerivativesListWithContext(ctx context.Context) (*GetDerivativesListResponse, error) {
 	var response GetDerivativesListResponse
 
-	if err := c.baseClient.Get(GetDerivativesListEndpoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "derivatives.GetDerivativesList",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetDerivativesListEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -40,9 +59,17 @@ func (c *ClientImpl) GetDerivativesList() (*GetDerivativesListResponse, error) {
 }
 
 func (c *ClientImpl) GetDerivativesExchangesList() (*GetDerivativesExchangesListResponse, error) {
+	return c.GetDerivativesExchangesListWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetDerivativesExchangesListWithContext(ctx context.Context) (*GetDerivativesExchangesListResponse, error) {
 	var response GetDerivativesExchangesListResponse
 
-	if err := c.baseClient.Get(GetDerivativesExchangesListEndpoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "derivatives.GetDerivativesExchangesList",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetDerivativesExchangesListEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -50,22 +77,30 @@ func (c *ClientImpl) GetDerivativesExchangesList() (*GetDerivativesExchangesList
 }
 
 func (c *ClientImpl) GetDerivativeExchangeData(request *GetDerivativeExchangeDataRequest) (*GetDerivativeExchangeDataResponse, error) {
+	return c.GetDerivativeExchangeDataWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetDerivativeExchangeDataWithContext(ctx context.Context, request *GetDerivativeExchangeDataRequest) (*GetDerivativeExchangeDataResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetDerivativeExchangeDataResponse
 
 	opts := &base.RequestOptions{
+		Operation: "derivatives.GetDerivativeExchangeData",
 		PathParams: map[string]string{
 			"id": request.ID,
 		},
-		QueryParams: map[string]string{
-			"include_tickers": fmt.Sprintf("%v", request.IncludeTickers),
-		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetDerivativeExchangeDataEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetDerivativeExchangeDataEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -73,9 +108,17 @@ func (c *ClientImpl) GetDerivativeExchangeData(request *GetDerivativeExchangeDat
 }
 
 func (c *ClientImpl) GetDerivativesExchangesListIDMap() (*GetDerivativesExchangesListIDMapResponse, error) {
+	return c.GetDerivativesExchangesListIDMapWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetDerivativesExchangesListIDMapWithContext(ctx context.Context) (*GetDerivativesExchangesListIDMapResponse, error) {
 	var response GetDerivativesExchangesListIDMapResponse
 
-	if err := c.baseClient.Get(GetDerivativesExchangesListIDEndpoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "derivatives.GetDerivativesExchangesListIDMap",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetDerivativesExchangesListIDEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
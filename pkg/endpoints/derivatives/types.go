@@ -1,6 +1,6 @@
 package derivatives
 
-import "github.com/go-playground/validator/v10"
+import "github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 
 // Derivative represents a single derivative
 type Derivative struct {
@@ -67,7 +67,7 @@ type GetDerivativeExchangeDataRequest struct {
 	// ID is the unique identifier of the exchange
 	ID string `json:"id" validate:"required"`
 	// IncludeTickers is whether to include tickers
-	IncludeTickers string `json:"include_tickers,omitempty" validate:"omitempty,oneof=unexpired all"`
+	IncludeTickers string `json:"include_tickers,omitempty" query:"include_tickers" validate:"omitempty,oneof=unexpired all"`
 }
 
 // GetDerivativeExchangeDataResponse represents the response from the Derivative Exchange Data API
@@ -155,6 +155,5 @@ type GetDerivativesExchangesListIDMapResponse map[string]DerivativeExchange
 
 // Validate validates the request parameters
 func (r *GetDerivativeExchangeDataRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
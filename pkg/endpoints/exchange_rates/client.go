@@ -1,6 +1,7 @@
 package exchange_rates
 
 import (
+	"context"
 	"fmt"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 )
@@ -12,7 +13,9 @@ const (
 
 type Client interface {
 	GetExchangeRates() (*GetExchangeRatesResponse, error)
+	GetExchangeRatesWithContext(ctx context.Context) (*GetExchangeRatesResponse, error)
 	GetExchangeRate(request *GetExchangeRateRequest) (*GetExchangeRateResponse, error)
+	GetExchangeRateWithContext(ctx context.Context, request *GetExchangeRateRequest) (*GetExchangeRateResponse, error)
 }
 
 type ClientImpl struct {
@@ -26,9 +29,17 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) GetExchangeRates() (*GetExchangeRatesResponse, error) {
+	return c.GetExchangeRatesWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetExchangeRatesWithContext(ctx context.Context) (*GetExchangeRatesResponse, error) {
 	var response GetExchangeRatesResponse
 
-	if err := c.baseClient.Get(GetExchangeRatesRequestPoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "exchange_rates.GetExchangeRates",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetExchangeRatesRequestPoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -36,6 +47,10 @@ func (c *ClientImpl) GetExchangeRates() (*GetExchangeRatesResponse, error) {
 }
 
 func (c *ClientImpl) GetExchangeRate(request *GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
+	return c.GetExchangeRateWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetExchangeRateWithContext(ctx context.Context, request *GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
@@ -43,12 +58,13 @@ func (c *ClientImpl) GetExchangeRate(request *GetExchangeRateRequest) (*GetExcha
 	var response GetExchangeRateResponse
 
 	opts := &base.RequestOptions{
+		Operation: "exchange_rates.GetExchangeRate",
 		PathParams: map[string]string{
 			"id": request.ID,
 		},
 	}
 
-	if err := c.baseClient.Get(GetExchangeRateEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetExchangeRateEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
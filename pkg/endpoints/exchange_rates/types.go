@@ -1,7 +1,7 @@
 package exchange_rates
 
 import (
-	"github.com/go-playground/validator/v10"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 )
 
 // ExchangeRate represents a single exchange rate
@@ -42,6 +42,5 @@ type GetExchangeRateResponse struct {
 
 // Validate validates the GetExchangeRateRequest
 func (r *GetExchangeRateRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
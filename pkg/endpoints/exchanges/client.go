@@ -1,8 +1,10 @@
 package exchanges
 
 import (
+	"context"
 	"fmt"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"time"
 )
 
 const (
@@ -13,12 +15,22 @@ const (
 	GetExchangeVolumeChartRequestPoint = "/exchanges/{id}/volume_chart"
 )
 
+// Default cache TTLs of endpoints whose data rarely changes
+func init() {
+	base.RegisterCacheTTL(GetExchangesListIDRequestPoint, 24*time.Hour)
+}
+
 type Client interface {
 	GetExchangesList(request *GetExchangesListRequest) (*GetExchangesListResponse, error)
+	GetExchangesListWithContext(ctx context.Context, request *GetExchangesListRequest) (*GetExchangesListResponse, error)
 	GetExchangesListID() (*GetExchangesListIDResponse, error)
+	GetExchangesListIDWithContext(ctx context.Context) (*GetExchangesListIDResponse, error)
 	GetExchangeData(request *GetExchangeDataRequest) (*GetExchangeDataResponse, error)
+	GetExchangeDataWithContext(ctx context.Context, request *GetExchangeDataRequest) (*GetExchangeDataResponse, error)
 	GetExchangeTickers(request *GetExchangeTickersRequest) (*GetExchangeTickersResponse, error)
+	GetExchangeTickersWithContext(ctx context.Context, request *GetExchangeTickersRequest) (*GetExchangeTickersResponse, error)
 	GetExchangeVolumeChart(request *GetExchangeVolumeChartRequest) (*GetExchangeVolumeChartResponse, error)
+	GetExchangeVolumeChartWithContext(ctx context.Context, request *GetExchangeVolumeChartRequest) (*GetExchangeVolumeChartResponse, error)
 }
 
 type ClientImpl struct {
@@ -32,20 +44,27 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) GetExchangesList(request *GetExchangesListRequest) (*GetExchangesListResponse, error) {
+	return c.GetExchangesListWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetExchangesListWithContext(ctx context.Context, request *GetExchangesListRequest) (*GetExchangesListResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetExchangesListResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"per_page": fmt.Sprintf("%d", request.PerPage),
-			"page":     fmt.Sprintf("%d", request.Page),
-		},
+		Operation:   "exchanges.GetExchangesList",
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetExchangesListRequestPoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetExchangesListRequestPoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -53,9 +72,17 @@ func (c *ClientImpl) GetExchangesList(request *GetExchangesListRequest) (*GetExc
 }
 
 func (c *ClientImpl) GetExchangesListID() (*GetExchangesListIDResponse, error) {
+	return c.GetExchangesListIDWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetExchangesListIDWithContext(ctx context.Context) (*GetExchangesListIDResponse, error) {
 	var response GetExchangesListIDResponse
 
-	if err := c.baseClient.Get(GetExchangesListIDRequestPoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "exchanges.GetExchangesListID",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetExchangesListIDRequestPoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -63,6 +90,10 @@ func (c *ClientImpl) GetExchangesListID() (*GetExchangesListIDResponse, error) {
 }
 
 func (c *ClientImpl) GetExchangeData(request *GetExchangeDataRequest) (*GetExchangeDataResponse, error) {
+	return c.GetExchangeDataWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetExchangeDataWithContext(ctx context.Context, request *GetExchangeDataRequest) (*GetExchangeDataResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
@@ -70,12 +101,13 @@ func (c *ClientImpl) GetExchangeData(request *GetExchangeDataRequest) (*GetExcha
 	var response GetExchangeDataResponse
 
 	opts := &base.RequestOptions{
+		Operation: "exchanges.GetExchangeData",
 		PathParams: map[string]string{
 			"id": request.ID,
 		},
 	}
 
-	if err := c.baseClient.Get(GetExchangeDataRequestPoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetExchangeDataRequestPoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -83,26 +115,30 @@ func (c *ClientImpl) GetExchangeData(request *GetExchangeDataRequest) (*GetExcha
 }
 
 func (c *ClientImpl) GetExchangeTickers(request *GetExchangeTickersRequest) (*GetExchangeTickersResponse, error) {
+	return c.GetExchangeTickersWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetExchangeTickersWithContext(ctx context.Context, request *GetExchangeTickersRequest) (*GetExchangeTickersResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetExchangeTickersResponse
 
 	opts := &base.RequestOptions{
+		Operation: "exchanges.GetExchangeTickers",
 		PathParams: map[string]string{
 			"id": request.ID,
 		},
-		QueryParams: map[string]string{
-			"coin_ids":              fmt.Sprintf("%v", request.CoinIDs),
-			"include_exchange_logo": fmt.Sprintf("%v", request.IncludeExchangeLogo),
-			"page":                  fmt.Sprintf("%d", request.Page),
-			"depth":                 fmt.Sprintf("%v", request.Depth),
-			"order":                 request.Order,
-		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetExchangeTickersRequestPoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetExchangeTickersRequestPoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -110,22 +146,30 @@ func (c *ClientImpl) GetExchangeTickers(request *GetExchangeTickersRequest) (*Ge
 }
 
 func (c *ClientImpl) GetExchangeVolumeChart(request *GetExchangeVolumeChartRequest) (*GetExchangeVolumeChartResponse, error) {
+	return c.GetExchangeVolumeChartWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetExchangeVolumeChartWithContext(ctx context.Context, request *GetExchangeVolumeChartRequest) (*GetExchangeVolumeChartResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetExchangeVolumeChartResponse
 
 	opts := &base.RequestOptions{
+		Operation: "exchanges.GetExchangeVolumeChart",
 		PathParams: map[string]string{
 			"id": request.ID,
 		},
-		QueryParams: map[string]string{
-			"days": fmt.Sprintf("%d", request.Days),
-		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetExchangeVolumeChartRequestPoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetExchangeVolumeChartRequestPoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,33 @@
+package exchanges_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/ipangpang/coingecko-v3/pkg/coingeckotest"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/exchanges"
+)
+
+func TestGetExchangeTickersQuery(t *testing.T) {
+	server := coingeckotest.NewServer()
+	t.Cleanup(server.Close)
+
+	var query url.Values
+	fixture := server.Fixture(exchanges.GetExchangeTickersRequestPoint)
+	server.Handle(exchanges.GetExchangeTickersRequestPoint, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(fixture)
+	})
+
+	client := exchanges.NewClient(base.NewBaseClient(base.DefaultConfig(), base.WithBaseURL(server.URL())))
+	if _, err := client.GetExchangeTickers(&exchanges.GetExchangeTickersRequest{ID: "binance", CoinIDs: []string{"a", "b"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := query.Encode(); got != "coin_ids=a%2Cb" {
+		t.Errorf("query = %s, want coin_ids=a,b only", got)
+	}
+}
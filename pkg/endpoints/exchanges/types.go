@@ -1,13 +1,13 @@
 package exchanges
 
-import "github.com/go-playground/validator/v10"
+import "github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 
 // GetExchangesListRequest represents the request parameters for getting exchanges list
 type GetExchangesListRequest struct {
 	// PerPage is the number of results per page
-	PerPage int `json:"per_page,omitempty" validate:"omitempty,min=1,max=250"`
+	PerPage int `json:"per_page,omitempty" query:"per_page" validate:"omitempty,min=1,max=250"`
 	// Page is the page number
-	Page int `json:"page,omitempty" validate:"omitempty,min=1"`
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1"`
 }
 
 // GetExchangesListResponse represents the response from the Exchanges List API
@@ -161,15 +161,15 @@ type GetExchangeTickersRequest struct {
 	// ID is the unique identifier of the exchange
 	ID string `json:"id" validate:"required"`
 	// CoinIDs is the list of coin IDs to filter by
-	CoinIDs []string `json:"coin_ids,omitempty"`
+	CoinIDs []string `json:"coin_ids,omitempty" query:"coin_ids"`
 	// IncludeExchangeLogo is whether to include exchange logo
-	IncludeExchangeLogo bool `json:"include_exchange_logo,omitempty"`
+	IncludeExchangeLogo bool `json:"include_exchange_logo,omitempty" query:"include_exchange_logo"`
 	// Page is the page number
-	Page int `json:"page,omitempty" validate:"omitempty,min=1"`
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1"`
 	// Depth is the depth of the order book
-	Depth bool `json:"depth,omitempty"`
+	Depth bool `json:"depth,omitempty" query:"depth"`
 	// Order is the order to sort by
-	Order string `json:"order,omitempty" validate:"omitempty,oneof=trust_score_desc trust_score_asc volume_desc volume_asc id_desc id_asc"`
+	Order string `json:"order,omitempty" query:"order" validate:"omitempty,oneof=trust_score_desc trust_score_asc volume_desc volume_asc id_desc id_asc"`
 }
 
 // GetExchangeTickersResponse represents the response from the Exchange Tickers API
@@ -185,7 +185,7 @@ type GetExchangeVolumeChartRequest struct {
 	// ID is the unique identifier of the exchange
 	ID string `json:"id" validate:"required"`
 	// Days is the number of days of data to return
-	Days string `json:"days" validate:"required"`
+	Days string `json:"days" query:"days" validate:"required,days"`
 }
 
 // GetExchangeVolumeChartResponse represents the response from the Exchange Volume Chart API
@@ -193,24 +193,20 @@ type GetExchangeVolumeChartResponse [][]interface{}
 
 // Validate validates the request parameters
 func (r *GetExchangesListRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 // Validate validates the request parameters
 func (r *GetExchangeDataRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 // Validate validates the request parameters
 func (r *GetExchangeTickersRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 // Validate validates the request parameters
 func (r *GetExchangeVolumeChartRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
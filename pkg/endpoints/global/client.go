@@ -1,6 +1,9 @@
 package global
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 )
 
@@ -12,8 +15,11 @@ const (
 
 type Client interface {
 	GetGlobal() (*GetGlobalResponse, error)
+	GetGlobalWithContext(ctx context.Context) (*GetGlobalResponse, error)
 	GetGlobalDefi() (*GetGlobalDefiResponse, error)
+	GetGlobalDefiWithContext(ctx context.Context) (*GetGlobalDefiResponse, error)
 	GetGlobalMarketCapChart(vsCurrency string, days string) (*GetGlobalMarketCapChartResponse, error)
+	GetGlobalMarketCapChartWithContext(ctx context.Context, vsCurrency string, days string) (*GetGlobalMarketCapChartResponse, error)
 }
 
 type ClientImpl struct {
@@ -27,9 +33,17 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) GetGlobal() (*GetGlobalResponse, error) {
+	return c.GetGlobalWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetGlobalWithContext(ctx context.Context) (*GetGlobalResponse, error) {
 	var response GetGlobalResponse
 
-	if err := c.baseClient.Get(GetGlobalRequestPoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "global.GetGlobal",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetGlobalRequestPoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -37,9 +51,17 @@ func (c *ClientImpl) GetGlobal() (*GetGlobalResponse, error) {
 }
 
 func (c *ClientImpl) GetGlobalDefi() (*GetGlobalDefiResponse, error) {
+	return c.GetGlobalDefiWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetGlobalDefiWithContext(ctx context.Context) (*GetGlobalDefiResponse, error) {
 	var response GetGlobalDefiResponse
 
-	if err := c.baseClient.Get(GetGlobalDefiRequestPoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "global.GetGlobalDefi",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetGlobalDefiRequestPoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -47,15 +69,30 @@ func (c *ClientImpl) GetGlobalDefi() (*GetGlobalDefiResponse, error) {
 }
 
 func (c *ClientImpl) GetGlobalMarketCapChart(vsCurrency string, days string) (*GetGlobalMarketCapChartResponse, error) {
+	return c.GetGlobalMarketCapChartWithContext(context.Background(), vsCurrency, days)
+}
+
+func (c *ClientImpl) GetGlobalMarketCapChartWithContext(ctx context.Context, vsCurrency string, days string) (*GetGlobalMarketCapChartResponse, error) {
+	request := struct {
+		VsCurrency string `json:"vs_currency" query:"vs_currency" validate:"omitempty,vs_currency"`
+		Days       string `json:"days" query:"days" validate:"required,days"`
+	}{vsCurrency, days}
+	if err := base.Validate(request); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"vs_currency": vsCurrency,
-			"days":        days,
-		},
+		Operation:   "global.GetGlobalMarketCapChart",
+		QueryParams: query,
 	}
 
 	var response GetGlobalMarketCapChartResponse
-	if err := c.baseClient.Get(GetGlobalMarketCapChartRequestPoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetGlobalMarketCapChartRequestPoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,8 @@
 package key
 
 import (
+	"context"
+
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 )
 
@@ -10,6 +12,7 @@ const (
 
 type Client interface {
 	Key() (*KeyResponse, error)
+	KeyWithContext(ctx context.Context) (*KeyResponse, error)
 }
 
 type ClientImpl struct {
@@ -23,9 +26,17 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) Key() (*KeyResponse, error) {
+	return c.KeyWithContext(context.Background())
+}
+
+func (c *ClientImpl) KeyWithContext(ctx context.Context) (*KeyResponse, error) {
 	var response KeyResponse
 
-	if err := c.baseClient.Get(KeyEndpoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "key.Key",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, KeyEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -1,8 +1,10 @@
 package nfts
 
 import (
+	"context"
 	"fmt"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"time"
 )
 
 const (
@@ -15,14 +17,26 @@ const (
 	GetNFTTickersEndpoint         = "/nfts/{id}/tickers"
 )
 
+// Default cache TTLs of endpoints whose data rarely changes
+func init() {
+	base.RegisterCacheTTL(GetNFTsListEndpoint, 24*time.Hour)
+}
+
 type Client interface {
 	GetNFTsList() (*GetNFTsListResponse, error)
+	GetNFTsListWithContext(ctx context.Context) (*GetNFTsListResponse, error)
 	GetNFTData(request *GetNFTDataRequest) (*GetNFTDataResponse, error)
+	GetNFTDataWithContext(ctx context.Context, request *GetNFTDataRequest) (*GetNFTDataResponse, error)
 	GetNFTContractData(request *GetNFTContractDataRequest) (*GetNFTContractDataResponse, error)
+	GetNFTContractDataWithContext(ctx context.Context, request *GetNFTContractDataRequest) (*GetNFTContractDataResponse, error)
 	GetNFTsMarketData(request *GetNFTsMarketDataRequest) (*GetNFTsMarketDataResponse, error)
+	GetNFTsMarketDataWithContext(ctx context.Context, request *GetNFTsMarketDataRequest) (*GetNFTsMarketDataResponse, error)
 	GetNFTHistory(request *GetNFTHistoryRequest) (*GetNFTHistoryResponse, error)
+	GetNFTHistoryWithContext(ctx context.Context, request *GetNFTHistoryRequest) (*GetNFTHistoryResponse, error)
 	GetNFTContractHistory(request *GetNFTContractHistoryRequest) (*GetNFTContractHistoryResponse, error)
+	GetNFTContractHistoryWithContext(ctx context.Context, request *GetNFTContractHistoryRequest) (*GetNFTContractHistoryResponse, error)
 	GetNFTTickers(request *GetNFTTickersRequest) (*GetNFTTickersResponse, error)
+	GetNFTTickersWithContext(ctx context.Context, request *GetNFTTickersRequest) (*GetNFTTickersResponse, error)
 }
 
 type ClientImpl struct {
@@ -36,9 +50,17 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) GetNFTsList() (*GetNFTsListResponse, error) {
+	return c.GetNFTsListWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetNFTsListWithContext(ctx context.Context) (*GetNFTsListResponse, error) {
 	var response GetNFTsListResponse
 
-	if err := c.baseClient.Get(GetNFTsListEndpoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "nfts.GetNFTsList",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetNFTsListEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -46,6 +68,10 @@ func (c *ClientImpl) GetNFTsList() (*GetNFTsListResponse, error) {
 }
 
 func (c *ClientImpl) GetNFTData(request *GetNFTDataRequest) (*GetNFTDataResponse, error) {
+	return c.GetNFTDataWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetNFTDataWithContext(ctx context.Context, request *GetNFTDataRequest) (*GetNFTDataResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
@@ -53,12 +79,13 @@ func (c *ClientImpl) GetNFTData(request *GetNFTDataRequest) (*GetNFTDataResponse
 	var response GetNFTDataResponse
 
 	opts := &base.RequestOptions{
+		Operation: "nfts.GetNFTData",
 		PathParams: map[string]string{
 			"id": request.ID,
 		},
 	}
 
-	if err := c.baseClient.Get(GetNFTDataEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetNFTDataEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -66,6 +93,10 @@ func (c *ClientImpl) GetNFTData(request *GetNFTDataRequest) (*GetNFTDataResponse
 }
 
 func (c *ClientImpl) GetNFTContractData(request *GetNFTContractDataRequest) (*GetNFTContractDataResponse, error) {
+	return c.GetNFTContractDataWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetNFTContractDataWithContext(ctx context.Context, request *GetNFTContractDataRequest) (*GetNFTContractDataResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
@@ -73,13 +104,14 @@ func (c *ClientImpl) GetNFTContractData(request *GetNFTContractDataRequest) (*Ge
 	var response GetNFTContractDataResponse
 
 	opts := &base.RequestOptions{
+		Operation: "nfts.GetNFTContractData",
 		PathParams: map[string]string{
 			"asset_platform_id": request.AssetPlatformID,
 			"contract_address":  request.ContractAddress,
 		},
 	}
 
-	if err := c.baseClient.Get(GetNFTContractDataEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetNFTContractDataEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -87,22 +119,27 @@ func (c *ClientImpl) GetNFTContractData(request *GetNFTContractDataRequest) (*Ge
 }
 
 func (c *ClientImpl) GetNFTsMarketData(request *GetNFTsMarketDataRequest) (*GetNFTsMarketDataResponse, error) {
+	return c.GetNFTsMarketDataWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetNFTsMarketDataWithContext(ctx context.Context, request *GetNFTsMarketDataRequest) (*GetNFTsMarketDataResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetNFTsMarketDataResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"order":     request.Order,
-			"per_page":  fmt.Sprintf("%d", request.PerPage),
-			"page":      fmt.Sprintf("%d", request.Page),
-			"sparkline": fmt.Sprintf("%v", request.Sparkline),
-		},
+		Operation:   "nfts.GetNFTsMarketData",
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetNFTsMarketDataEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetNFTsMarketDataEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -110,23 +147,30 @@ func (c *ClientImpl) GetNFTsMarketData(request *GetNFTsMarketDataRequest) (*GetN
 }
 
 func (c *ClientImpl) GetNFTHistory(request *GetNFTHistoryRequest) (*GetNFTHistoryResponse, error) {
+	return c.GetNFTHistoryWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetNFTHistoryWithContext(ctx context.Context, request *GetNFTHistoryRequest) (*GetNFTHistoryResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetNFTHistoryResponse
 
 	opts := &base.RequestOptions{
+		Operation: "nfts.GetNFTHistory",
 		PathParams: map[string]string{
 			"id": request.ID,
 		},
-		QueryParams: map[string]string{
-			"vs_currency": request.VsCurrency,
-			"days":        request.Days,
-		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetNFTHistoryEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetNFTHistoryEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -134,24 +178,31 @@ func (c *ClientImpl) GetNFTHistory(request *GetNFTHistoryRequest) (*GetNFTHistor
 }
 
 func (c *ClientImpl) GetNFTContractHistory(request *GetNFTContractHistoryRequest) (*GetNFTContractHistoryResponse, error) {
+	return c.GetNFTContractHistoryWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetNFTContractHistoryWithContext(ctx context.Context, request *GetNFTContractHistoryRequest) (*GetNFTContractHistoryResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetNFTContractHistoryResponse
 
 	opts := &base.RequestOptions{
+		Operation: "nfts.GetNFTContractHistory",
 		PathParams: map[string]string{
 			"asset_platform_id": request.AssetPlatformID,
 			"contract_address":  request.ContractAddress,
 		},
-		QueryParams: map[string]string{
-			"vs_currency": request.VsCurrency,
-			"days":        request.Days,
-		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetNFTContractHistoryEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetNFTContractHistoryEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -159,25 +210,30 @@ func (c *ClientImpl) GetNFTContractHistory(request *GetNFTContractHistoryRequest
 }
 
 func (c *ClientImpl) GetNFTTickers(request *GetNFTTickersRequest) (*GetNFTTickersResponse, error) {
+	return c.GetNFTTickersWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetNFTTickersWithContext(ctx context.Context, request *GetNFTTickersRequest) (*GetNFTTickersResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetNFTTickersResponse
 
 	opts := &base.RequestOptions{
+		Operation: "nfts.GetNFTTickers",
 		PathParams: map[string]string{
 			"id": request.ID,
 		},
-		QueryParams: map[string]string{
-			"exchange_ids":          request.ExchangeIDs,
-			"include_exchange_logo": fmt.Sprintf("%v", request.IncludeExchangeLogo),
-			"order":                 request.Order,
-			"depth":                 fmt.Sprintf("%v", request.Depth),
-		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetNFTTickersEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetNFTTickersEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,6 @@
 package nfts
 
-import "github.com/go-playground/validator/v10"
+import "github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 
 // NFT represents a single NFT
 type NFT struct {
@@ -70,7 +70,7 @@ type GetNFTContractDataRequest struct {
 	// AssetPlatformID is the asset platform ID
 	AssetPlatformID string `json:"asset_platform_id" validate:"required"`
 	// ContractAddress is the contract address of the NFT
-	ContractAddress string `json:"contract_address" validate:"required"`
+	ContractAddress string `json:"contract_address" validate:"required,address=AssetPlatformID"`
 }
 
 // GetNFTContractDataResponse represents the response from the NFT Contract Data API
@@ -116,13 +116,13 @@ type GetNFTContractDataResponse struct {
 // GetNFTsMarketDataRequest represents the request parameters for getting NFTs market data
 type GetNFTsMarketDataRequest struct {
 	// Order is the order to sort by
-	Order string `json:"order,omitempty" validate:"omitempty,oneof=market_cap_desc market_cap_asc volume_desc volume_asc id_desc id_asc"`
+	Order string `json:"order,omitempty" query:"order" validate:"omitempty,oneof=market_cap_desc market_cap_asc volume_desc volume_asc id_desc id_asc"`
 	// PerPage is the number of results per page
-	PerPage int `json:"per_page,omitempty" validate:"omitempty,min=1,max=250"`
+	PerPage int `json:"per_page,omitempty" query:"per_page" validate:"omitempty,min=1,max=250"`
 	// Page is the page number
-	Page int `json:"page,omitempty" validate:"omitempty,min=1"`
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1"`
 	// Sparkline is whether to include sparkline data
-	Sparkline bool `json:"sparkline,omitempty"`
+	Sparkline bool `json:"sparkline,omitempty" query:"sparkline"`
 }
 
 // GetNFTsMarketDataResponse represents the response from the NFTs Market Data API
@@ -187,9 +187,9 @@ type GetNFTHistoryRequest struct {
 	// ID is the unique identifier of the NFT
 	ID string `json:"id" validate:"required"`
 	// VsCurrency is the target currency of market data
-	VsCurrency string `json:"vs_currency" validate:"required"`
+	VsCurrency string `json:"vs_currency" query:"vs_currency" validate:"required,vs_currency"`
 	// Days is the data up to number of days ago
-	Days string `json:"days" validate:"required"`
+	Days string `json:"days" query:"days" validate:"required,days"`
 }
 
 // GetNFTHistoryResponse represents the response from the NFT History API
@@ -217,11 +217,11 @@ type GetNFTContractHistoryRequest struct {
 	// AssetPlatformID is the asset platform ID
 	AssetPlatformID string `json:"asset_platform_id" validate:"required"`
 	// ContractAddress is the contract address of the NFT
-	ContractAddress string `json:"contract_address" validate:"required"`
+	ContractAddress string `json:"contract_address" validate:"required,address=AssetPlatformID"`
 	// VsCurrency is the target currency of market data
-	VsCurrency string `json:"vs_currency" validate:"required"`
+	VsCurrency string `json:"vs_currency" query:"vs_currency" validate:"required,vs_currency"`
 	// Days is the data up to number of days ago
-	Days string `json:"days" validate:"required"`
+	Days string `json:"days" query:"days" validate:"required,days"`
 }
 
 // GetNFTContractHistoryResponse represents the response from the NFT Contract History API
@@ -249,13 +249,13 @@ type GetNFTTickersRequest struct {
 	// ID is the unique identifier of the NFT
 	ID string `json:"id" validate:"required"`
 	// ExchangeIDs is the list of exchange IDs to filter by
-	ExchangeIDs string `json:"exchange_ids,omitempty"`
+	ExchangeIDs string `json:"exchange_ids,omitempty" query:"exchange_ids"`
 	// IncludeExchangeLogo is whether to include exchange logo
-	IncludeExchangeLogo bool `json:"include_exchange_logo,omitempty"`
+	IncludeExchangeLogo bool `json:"include_exchange_logo,omitempty" query:"include_exchange_logo"`
 	// Order is the order to sort by
-	Order string `json:"order,omitempty" validate:"omitempty,oneof=trust_score_desc trust_score_asc volume_desc volume_asc"`
+	Order string `json:"order,omitempty" query:"order" validate:"omitempty,oneof=trust_score_desc trust_score_asc volume_desc volume_asc"`
 	// Depth is the depth of order book
-	Depth bool `json:"depth,omitempty"`
+	Depth bool `json:"depth,omitempty" query:"depth"`
 }
 
 // GetNFTTickersResponse represents the response from the NFT Tickers API
@@ -372,36 +372,30 @@ type Sparkline struct {
 
 // Validate validates the request parameters
 func (r *GetNFTDataRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 // Validate validates the request parameters
 func (r *GetNFTContractDataRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 // Validate validates the request parameters
 func (r *GetNFTsMarketDataRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 // Validate validates the request parameters
 func (r *GetNFTHistoryRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 // Validate validates the request parameters
 func (r *GetNFTContractHistoryRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 // Validate validates the request parameters
 func (r *GetNFTTickersRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
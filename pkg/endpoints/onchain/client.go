@@ -0,0 +1,802 @@
+package onchain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+)
+
+const (
+	GetNetworksEndpoint             = "/onchain/networks"
+	GetNetworkDexesEndpoint         = "/onchain/networks/{network}/dexes"
+	GetPoolEndpoint                 = "/onchain/networks/{network}/pools/{address}"
+	GetMultiplePoolsEndpoint        = "/onchain/networks/{network}/pools/multi/{addresses}"
+	SearchPoolsEndpoint             = "/onchain/search/pools"
+	GetTrendingPoolsEndpoint        = "/onchain/networks/trending_pools"
+	GetNetworkTrendingPoolsEndpoint = "/onchain/networks/{network}/trending_pools"
+	GetTopPoolsEndpoint             = "/onchain/networks/{network}/pools"
+	GetDexTopPoolsEndpoint          = "/onchain/networks/{network}/dexes/{dex}/pools"
+	GetNewPoolsEndpoint             = "/onchain/networks/new_pools"
+	GetNetworkNewPoolsEndpoint      = "/onchain/networks/{network}/new_pools"
+	GetPoolOHLCVEndpoint            = "/onchain/networks/{network}/pools/{pool}/ohlcv/{timeframe}"
+	GetPoolTradesEndpoint           = "/onchain/networks/{network}/pools/{pool}/trades"
+	GetTokenEndpoint                = "/onchain/networks/{network}/tokens/{address}"
+	GetMultipleTokensEndpoint       = "/onchain/networks/{network}/tokens/multi/{addresses}"
+	GetTokenInfoEndpoint            = "/onchain/networks/{network}/tokens/{address}/info"
+	GetTokenPoolsEndpoint           = "/onchain/networks/{network}/tokens/{address}/pools"
+	GetTokenPriceEndpoint           = "/onchain/simple/networks/{network}/token_price/{addresses}"
+)
+
+// Default cache TTLs of endpoints whose data rarely changes
+func init() {
+	base.RegisterCacheTTL(GetNetworksEndpoint, 24*time.Hour)
+	base.RegisterCacheTTL(GetNetworkDexesEndpoint, 24*time.Hour)
+}
+
+type Client interface {
+	GetNetworks(request *GetNetworksRequest) (*GetNetworksResponse, error)
+	GetNetworksWithContext(ctx context.Context, request *GetNetworksRequest) (*GetNetworksResponse, error)
+	GetNetworkDexes(request *GetNetworkDexesRequest) (*GetNetworkDexesResponse, error)
+	GetNetworkDexesWithContext(ctx context.Context, request *GetNetworkDexesRequest) (*GetNetworkDexesResponse, error)
+	GetPool(request *GetPoolRequest) (*GetPoolResponse, error)
+	GetPoolWithContext(ctx context.Context, request *GetPoolRequest) (*GetPoolResponse, error)
+	GetMultiplePools(request *GetMultiplePoolsRequest) (*GetMultiplePoolsResponse, error)
+	GetMultiplePoolsWithContext(ctx context.Context, request *GetMultiplePoolsRequest) (*GetMultiplePoolsResponse, error)
+	SearchPools(request *SearchPoolsRequest) (*SearchPoolsResponse, error)
+	SearchPoolsWithContext(ctx context.Context, request *SearchPoolsRequest) (*SearchPoolsResponse, error)
+	GetTrendingPools(request *GetTrendingPoolsRequest) (*GetTrendingPoolsResponse, error)
+	GetTrendingPoolsWithContext(ctx context.Context, request *GetTrendingPoolsRequest) (*GetTrendingPoolsResponse, error)
+	GetNetworkTrendingPools(request *GetNetworkTrendingPoolsRequest) (*GetNetworkTrendingPoolsResponse, error)
+	GetNetworkTrendingPoolsWithContext(ctx context.Context, request *GetNetworkTrendingPoolsRequest) (*GetNetworkTrendingPoolsResponse, error)
+	GetTopPools(request *GetTopPoolsRequest) (*GetTopPoolsResponse, error)
+	GetTopPoolsWithContext(ctx context.Context, request *GetTopPoolsRequest) (*GetTopPoolsResponse, error)
+	GetDexTopPools(request *GetDexTopPoolsRequest) (*GetDexTopPoolsResponse, error)
+	GetDexTopPoolsWithContext(ctx context.Context, request *GetDexTopPoolsRequest) (*GetDexTopPoolsResponse, error)
+	GetNewPools(request *GetNewPoolsRequest) (*GetNewPoolsResponse, error)
+	GetNewPoolsWithContext(ctx context.Context, request *GetNewPoolsRequest) (*GetNewPoolsResponse, error)
+	GetNetworkNewPools(request *GetNetworkNewPoolsRequest) (*GetNetworkNewPoolsResponse, error)
+	GetNetworkNewPoolsWithContext(ctx context.Context, request *GetNetworkNewPoolsRequest) (*GetNetworkNewPoolsResponse, error)
+	TrendingPoolsPages(request *GetTrendingPoolsRequest) *PoolPager
+	NetworkTrendingPoolsPages(request *GetNetworkTrendingPoolsRequest) *PoolPager
+	TopPoolsPages(request *GetTopPoolsRequest) *PoolPager
+	DexTopPoolsPages(request *GetDexTopPoolsRequest) *PoolPager
+	NewPoolsPages(request *GetNewPoolsRequest) *PoolPager
+	NetworkNewPoolsPages(request *GetNetworkNewPoolsRequest) *PoolPager
+	GetPoolOHLCV(request *GetPoolOHLCVRequest) (*GetPoolOHLCVResponse, error)
+	GetPoolOHLCVWithContext(ctx context.Context, request *GetPoolOHLCVRequest) (*GetPoolOHLCVResponse, error)
+	PoolOHLCVPages(request *GetPoolOHLCVRequest) *OHLCVPager
+	GetPoolTrades(request *GetPoolTradesRequest) (*GetPoolTradesResponse, error)
+	GetPoolTradesWithContext(ctx context.Context, request *GetPoolTradesRequest) (*GetPoolTradesResponse, error)
+	GetToken(request *GetTokenRequest) (*GetTokenResponse, error)
+	GetTokenWithContext(ctx context.Context, request *GetTokenRequest) (*GetTokenResponse, error)
+	GetMultipleTokens(request *GetMultipleTokensRequest) (*GetMultipleTokensResponse, error)
+	GetMultipleTokensWithContext(ctx context.Context, request *GetMultipleTokensRequest) (*GetMultipleTokensResponse, error)
+	GetTokenInfo(request *GetTokenInfoRequest) (*GetTokenInfoResponse, error)
+	GetTokenInfoWithContext(ctx context.Context, request *GetTokenInfoRequest) (*GetTokenInfoResponse, error)
+	GetTokenPools(request *GetTokenPoolsRequest) (*GetTokenPoolsResponse, error)
+	GetTokenPoolsWithContext(ctx context.Context, request *GetTokenPoolsRequest) (*GetTokenPoolsResponse, error)
+	TokenPoolsPages(request *GetTokenPoolsRequest) *PoolPager
+	GetTokenPrice(request *GetTokenPriceRequest) (*GetTokenPriceResponse, error)
+	GetTokenPriceWithContext(ctx context.Context, request *GetTokenPriceRequest) (*GetTokenPriceResponse, error)
+}
+
+type ClientImpl struct {
+	baseClient *base.BaseClient
+}
+
+func NewClient(baseClient *base.BaseClient) Client {
+	return &ClientImpl{
+		baseClient: baseClient,
+	}
+}
+
+func (c *ClientImpl) GetNetworks(request *GetNetworksRequest) (*GetNetworksResponse, error) {
+	return c.GetNetworksWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetNetworksWithContext(ctx context.Context, request *GetNetworksRequest) (*GetNetworksResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetNetworksResponse
+
+	opts := &base.RequestOptions{
+		Operation:   "onchain.GetNetworks",
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetNetworksEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetNetworkDexes(request *GetNetworkDexesRequest) (*GetNetworkDexesResponse, error) {
+	return c.GetNetworkDexesWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetNetworkDexesWithContext(ctx context.Context, request *GetNetworkDexesRequest) (*GetNetworkDexesResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetNetworkDexesResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetNetworkDexes",
+		PathParams: map[string]string{
+			"network": request.Network,
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetNetworkDexesEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetPool(request *GetPoolRequest) (*GetPoolResponse, error) {
+	return c.GetPoolWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetPoolWithContext(ctx context.Context, request *GetPoolRequest) (*GetPoolResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetPoolResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetPool",
+		PathParams: map[string]string{
+			"network": request.Network,
+			"address": request.Address,
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetPoolEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetMultiplePools(request *GetMultiplePoolsRequest) (*GetMultiplePoolsResponse, error) {
+	return c.GetMultiplePoolsWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetMultiplePoolsWithContext(ctx context.Context, request *GetMultiplePoolsRequest) (*GetMultiplePoolsResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetMultiplePoolsResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetMultiplePools",
+		PathParams: map[string]string{
+			"network":   request.Network,
+			"addresses": strings.Join(request.Addresses, ","),
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetMultiplePoolsEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) SearchPools(request *SearchPoolsRequest) (*SearchPoolsResponse, error) {
+	return c.SearchPoolsWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) SearchPoolsWithContext(ctx context.Context, request *SearchPoolsRequest) (*SearchPoolsResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response SearchPoolsResponse
+
+	opts := &base.RequestOptions{
+		Operation:   "onchain.SearchPools",
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, SearchPoolsEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetTrendingPools(request *GetTrendingPoolsRequest) (*GetTrendingPoolsResponse, error) {
+	return c.GetTrendingPoolsWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetTrendingPoolsWithContext(ctx context.Context, request *GetTrendingPoolsRequest) (*GetTrendingPoolsResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetTrendingPoolsResponse
+
+	opts := &base.RequestOptions{
+		Operation:   "onchain.GetTrendingPools",
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetTrendingPoolsEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetNetworkTrendingPools(request *GetNetworkTrendingPoolsRequest) (*GetNetworkTrendingPoolsResponse, error) {
+	return c.GetNetworkTrendingPoolsWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetNetworkTrendingPoolsWithContext(ctx context.Context, request *GetNetworkTrendingPoolsRequest) (*GetNetworkTrendingPoolsResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetNetworkTrendingPoolsResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetNetworkTrendingPools",
+		PathParams: map[string]string{
+			"network": request.Network,
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetNetworkTrendingPoolsEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetTopPools(request *GetTopPoolsRequest) (*GetTopPoolsResponse, error) {
+	return c.GetTopPoolsWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetTopPoolsWithContext(ctx context.Context, request *GetTopPoolsRequest) (*GetTopPoolsResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetTopPoolsResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetTopPools",
+		PathParams: map[string]string{
+			"network": request.Network,
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetTopPoolsEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetDexTopPools(request *GetDexTopPoolsRequest) (*GetDexTopPoolsResponse, error) {
+	return c.GetDexTopPoolsWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetDexTopPoolsWithContext(ctx context.Context, request *GetDexTopPoolsRequest) (*GetDexTopPoolsResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetDexTopPoolsResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetDexTopPools",
+		PathParams: map[string]string{
+			"network": request.Network,
+			"dex":     request.Dex,
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetDexTopPoolsEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetNewPools(request *GetNewPoolsRequest) (*GetNewPoolsResponse, error) {
+	return c.GetNewPoolsWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetNewPoolsWithContext(ctx context.Context, request *GetNewPoolsRequest) (*GetNewPoolsResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetNewPoolsResponse
+
+	opts := &base.RequestOptions{
+		Operation:   "onchain.GetNewPools",
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetNewPoolsEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetNetworkNewPools(request *GetNetworkNewPoolsRequest) (*GetNetworkNewPoolsResponse, error) {
+	return c.GetNetworkNewPoolsWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetNetworkNewPoolsWithContext(ctx context.Context, request *GetNetworkNewPoolsRequest) (*GetNetworkNewPoolsResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetNetworkNewPoolsResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetNetworkNewPools",
+		PathParams: map[string]string{
+			"network": request.Network,
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetNetworkNewPoolsEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// TrendingPoolsPages returns a pager over the pages of GetTrendingPools, starting at request.Page
+func (c *ClientImpl) TrendingPoolsPages(request *GetTrendingPoolsRequest) *PoolPager {
+	var paged GetTrendingPoolsRequest
+	if request != nil {
+		paged = *request
+	}
+	return NewPoolPager(func(ctx context.Context, page int) (*PoolsResponse, error) {
+		paged.Page = page
+		return c.GetTrendingPoolsWithContext(ctx, &paged)
+	}, paged.Page)
+}
+
+// NetworkTrendingPoolsPages returns a pager over the pages of GetNetworkTrendingPools, starting at request.Page
+func (c *ClientImpl) NetworkTrendingPoolsPages(request *GetNetworkTrendingPoolsRequest) *PoolPager {
+	var paged GetNetworkTrendingPoolsRequest
+	if request != nil {
+		paged = *request
+	}
+	return NewPoolPager(func(ctx context.Context, page int) (*PoolsResponse, error) {
+		paged.Page = page
+		return c.GetNetworkTrendingPoolsWithContext(ctx, &paged)
+	}, paged.Page)
+}
+
+// TopPoolsPages returns a pager over the pages of GetTopPools, starting at request.Page
+func (c *ClientImpl) TopPoolsPages(request *GetTopPoolsRequest) *PoolPager {
+	var paged GetTopPoolsRequest
+	if request != nil {
+		paged = *request
+	}
+	return NewPoolPager(func(ctx context.Context, page int) (*PoolsResponse, error) {
+		paged.Page = page
+		return c.GetTopPoolsWithContext(ctx, &paged)
+	}, paged.Page)
+}
+
+// DexTopPoolsPages returns a pager over the pages of GetDexTopPools, starting at request.Page
+func (c *ClientImpl) DexTopPoolsPages(request *GetDexTopPoolsRequest) *PoolPager {
+	var paged GetDexTopPoolsRequest
+	if request != nil {
+		paged = *request
+	}
+	return NewPoolPager(func(ctx context.Context, page int) (*PoolsResponse, error) {
+		paged.Page = page
+		return c.GetDexTopPoolsWithContext(ctx, &paged)
+	}, paged.Page)
+}
+
+// NewPoolsPages returns a pager over the pages of GetNewPools, starting at request.Page
+func (c *ClientImpl) NewPoolsPages(request *GetNewPoolsRequest) *PoolPager {
+	var paged GetNewPoolsRequest
+	if request != nil {
+		paged = *request
+	}
+	return NewPoolPager(func(ctx context.Context, page int) (*PoolsResponse, error) {
+		paged.Page = page
+		return c.GetNewPoolsWithContext(ctx, &paged)
+	}, paged.Page)
+}
+
+// NetworkNewPoolsPages returns a pager over the pages of GetNetworkNewPools, starting at request.Page
+func (c *ClientImpl) NetworkNewPoolsPages(request *GetNetworkNewPoolsRequest) *PoolPager {
+	var paged GetNetworkNewPoolsRequest
+	if request != nil {
+		paged = *request
+	}
+	return NewPoolPager(func(ctx context.Context, page int) (*PoolsResponse, error) {
+		paged.Page = page
+		return c.GetNetworkNewPoolsWithContext(ctx, &paged)
+	}, paged.Page)
+}
+
+func (c *ClientImpl) GetPoolOHLCV(request *GetPoolOHLCVRequest) (*GetPoolOHLCVResponse, error) {
+	return c.GetPoolOHLCVWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetPoolOHLCVWithContext(ctx context.Context, request *GetPoolOHLCVRequest) (*GetPoolOHLCVResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetPoolOHLCVResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetPoolOHLCV",
+		PathParams: map[string]string{
+			"network":   request.Network,
+			"pool":      request.Pool,
+			"timeframe": request.Timeframe,
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetPoolOHLCVEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// PoolOHLCVPages returns a pager walking the candles of GetPoolOHLCV backwards from request.BeforeTimestamp
+func (c *ClientImpl) PoolOHLCVPages(request *GetPoolOHLCVRequest) *OHLCVPager {
+	var paged GetPoolOHLCVRequest
+	if request != nil {
+		paged = *request
+	}
+	return NewOHLCVPager(func(ctx context.Context, before time.Time) (*GetPoolOHLCVResponse, error) {
+		paged.BeforeTimestamp = before
+		return c.GetPoolOHLCVWithContext(ctx, &paged)
+	}, paged.BeforeTimestamp)
+}
+
+func (c *ClientImpl) GetPoolTrades(request *GetPoolTradesRequest) (*GetPoolTradesResponse, error) {
+	return c.GetPoolTradesWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetPoolTradesWithContext(ctx context.Context, request *GetPoolTradesRequest) (*GetPoolTradesResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetPoolTradesResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetPoolTrades",
+		PathParams: map[string]string{
+			"network": request.Network,
+			"pool":    request.Pool,
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetPoolTradesEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetToken(request *GetTokenRequest) (*GetTokenResponse, error) {
+	return c.GetTokenWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetTokenWithContext(ctx context.Context, request *GetTokenRequest) (*GetTokenResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetTokenResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetToken",
+		PathParams: map[string]string{
+			"network": request.Network,
+			"address": request.Address,
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetTokenEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetMultipleTokens(request *GetMultipleTokensRequest) (*GetMultipleTokensResponse, error) {
+	return c.GetMultipleTokensWithContext(context.Background(), request)
+}
+
+// GetMultipleTokensWithContext sends one request per MaxMultipleTokensAddresses addresses and merges the responses
+func (c *ClientImpl) GetMultipleTokensWithContext(ctx context.Context, request *GetMultipleTokensRequest) (*GetMultipleTokensResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetMultipleTokensResponse
+
+	// The ResponseMeta of ctx adds up the requests of all chunks
+	ctx = base.WithAggregatedResponseMeta(ctx)
+	for _, addresses := range chunkAddresses(request.Addresses, MaxMultipleTokensAddresses) {
+		var chunk GetMultipleTokensResponse
+
+		opts := &base.RequestOptions{
+			Operation: "onchain.GetMultipleTokens",
+			PathParams: map[string]string{
+				"network":   request.Network,
+				"addresses": strings.Join(addresses, ","),
+			},
+			QueryParams: query,
+		}
+
+		if err := c.baseClient.GetWithContext(ctx, GetMultipleTokensEndpoint, opts, &chunk); err != nil {
+			return nil, err
+		}
+
+		response.Data = append(response.Data, chunk.Data...)
+		response.Included = response.Included.merge(chunk.Included)
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetTokenInfo(request *GetTokenInfoRequest) (*GetTokenInfoResponse, error) {
+	return c.GetTokenInfoWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetTokenInfoWithContext(ctx context.Context, request *GetTokenInfoRequest) (*GetTokenInfoResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetTokenInfoResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetTokenInfo",
+		PathParams: map[string]string{
+			"network": request.Network,
+			"address": request.Address,
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetTokenInfoEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *ClientImpl) GetTokenPools(request *GetTokenPoolsRequest) (*GetTokenPoolsResponse, error) {
+	return c.GetTokenPoolsWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetTokenPoolsWithContext(ctx context.Context, request *GetTokenPoolsRequest) (*GetTokenPoolsResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetTokenPoolsResponse
+
+	opts := &base.RequestOptions{
+		Operation: "onchain.GetTokenPools",
+		PathParams: map[string]string{
+			"network": request.Network,
+			"address": request.Address,
+		},
+		QueryParams: query,
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetTokenPoolsEndpoint, opts, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// TokenPoolsPages returns a pager over the pages of GetTokenPools, starting at request.Page
+func (c *ClientImpl) TokenPoolsPages(request *GetTokenPoolsRequest) *PoolPager {
+	var paged GetTokenPoolsRequest
+	if request != nil {
+		paged = *request
+	}
+	return NewPoolPager(func(ctx context.Context, page int) (*PoolsResponse, error) {
+		paged.Page = page
+		return c.GetTokenPoolsWithContext(ctx, &paged)
+	}, paged.Page)
+}
+
+func (c *ClientImpl) GetTokenPrice(request *GetTokenPriceRequest) (*GetTokenPriceResponse, error) {
+	return c.GetTokenPriceWithContext(context.Background(), request)
+}
+
+// GetTokenPriceWithContext sends one request per MaxTokenPriceAddresses addresses and merges the responses
+func (c *ClientImpl) GetTokenPriceWithContext(ctx context.Context, request *GetTokenPriceRequest) (*GetTokenPriceResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	var response GetTokenPriceResponse
+
+	// The ResponseMeta of ctx adds up the requests of all chunks
+	ctx = base.WithAggregatedResponseMeta(ctx)
+	for _, addresses := range chunkAddresses(request.Addresses, MaxTokenPriceAddresses) {
+		var chunk GetTokenPriceResponse
+
+		opts := &base.RequestOptions{
+			Operation: "onchain.GetTokenPrice",
+			PathParams: map[string]string{
+				"network":   request.Network,
+				"addresses": strings.Join(addresses, ","),
+			},
+			QueryParams: query,
+		}
+
+		if err := c.baseClient.GetWithContext(ctx, GetTokenPriceEndpoint, opts, &chunk); err != nil {
+			return nil, err
+		}
+
+		if response.Data.ID == "" {
+			response.Data.ID = chunk.Data.ID
+			response.Data.Type = chunk.Data.Type
+		}
+		attributes := &response.Data.Attributes
+		attributes.TokenPrices = mergeDecimals(attributes.TokenPrices, chunk.Data.Attributes.TokenPrices)
+		attributes.MarketCapUSD = mergeDecimals(attributes.MarketCapUSD, chunk.Data.Attributes.MarketCapUSD)
+		attributes.H24VolumeUSD = mergeDecimals(attributes.H24VolumeUSD, chunk.Data.Attributes.H24VolumeUSD)
+		attributes.H24PriceChangePercentage = mergeDecimals(attributes.H24PriceChangePercentage, chunk.Data.Attributes.H24PriceChangePercentage)
+		attributes.TotalReserveInUSD = mergeDecimals(attributes.TotalReserveInUSD, chunk.Data.Attributes.TotalReserveInUSD)
+	}
+
+	return &response, nil
+}
+
+// chunkAddresses splits addresses into lists of at most size addresses
+func chunkAddresses(addresses []string, size int) [][]string {
+	var chunks [][]string
+	for len(addresses) > size {
+		chunks = append(chunks, addresses[:size])
+		addresses = addresses[size:]
+	}
+	if len(addresses) > 0 {
+		chunks = append(chunks, addresses)
+	}
+	return chunks
+}
+
+// mergeDecimals adds the values of src to dst, allocating dst when needed
+func mergeDecimals(dst, src map[string]Decimal) map[string]Decimal {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]Decimal, len(src))
+	}
+	for key, value := range src {
+		dst[key] = value
+	}
+	return dst
+}
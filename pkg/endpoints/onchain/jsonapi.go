@@ -0,0 +1,177 @@
+package onchain
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Resource types of the onchain API
+const (
+	ResourceTypeNetwork = "network"
+	ResourceTypeDex     = "dex"
+	ResourceTypePool    = "pool"
+	ResourceTypeToken   = "token"
+)
+
+// Include values accepted by the include parameter of pool and token endpoints
+const (
+	IncludeBaseToken  = "base_token"
+	IncludeQuoteToken = "quote_token"
+	IncludeDex        = "dex"
+	IncludeTopPools   = "top_pools"
+)
+
+// Decimal is a decimal number sent as a string by the API to preserve its precision
+type Decimal string
+
+// Float64 returns the number as a float64, 0 when the API sent no value
+func (d Decimal) Float64() (float64, error) {
+	if d == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(string(d), 64)
+}
+
+// UnmarshalJSON accepts numbers sent either as strings or as JSON numbers, and null
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = ""
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*d = Decimal(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*d = Decimal(n)
+	return nil
+}
+
+// ResourceIdentifier identifies a resource of a JSON:API document
+type ResourceIdentifier struct {
+	// ID is the identifier of the resource, e.g. "eth_0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640"
+	ID string `json:"id"`
+	// Type is the resource type, e.g. "pool"
+	Type string `json:"type"`
+}
+
+// Relationship links a resource to another resource
+type Relationship struct {
+	// Data identifies the related resource, nil when there is none
+	Data *ResourceIdentifier `json:"data"`
+}
+
+// RelationshipList links a resource to several resources
+type RelationshipList struct {
+	// Data identifies the related resources
+	Data []ResourceIdentifier `json:"data"`
+}
+
+// IncludedResource is a related resource returned alongside the primary data when requested with include
+type IncludedResource struct {
+	// ID is the identifier of the resource
+	ID string `json:"id"`
+	// Type is the resource type, e.g. "token" or "dex"
+	Type string `json:"type"`
+	// Attributes are the raw attributes of the resource, decoded by the Included helpers
+	Attributes json.RawMessage `json:"attributes"`
+	// Relationships are the relationships of the resource
+	Relationships map[string]json.RawMessage `json:"relationships,omitempty"`
+}
+
+// Included are the related resources of a response
+type Included []IncludedResource
+
+// find returns the included resource identified by ref
+func (in Included) find(ref *ResourceIdentifier) (*IncludedResource, bool) {
+	if ref == nil {
+		return nil, false
+	}
+	for i := range in {
+		if in[i].ID == ref.ID && in[i].Type == ref.Type {
+			return &in[i], true
+		}
+	}
+	return nil, false
+}
+
+// Token returns the included token rel points to, false when it was not included
+func (in Included) Token(rel Relationship) (*Token, bool) {
+	resource, ok := in.find(rel.Data)
+	if !ok {
+		return nil, false
+	}
+
+	token := &Token{ID: resource.ID, Type: resource.Type}
+	if err := json.Unmarshal(resource.Attributes, &token.Attributes); err != nil {
+		return nil, false
+	}
+	return token, true
+}
+
+// Dex returns the included dex rel points to, false when it was not included
+func (in Included) Dex(rel Relationship) (*Dex, bool) {
+	resource, ok := in.find(rel.Data)
+	if !ok {
+		return nil, false
+	}
+
+	dex := &Dex{ID: resource.ID, Type: resource.Type}
+	if err := json.Unmarshal(resource.Attributes, &dex.Attributes); err != nil {
+		return nil, false
+	}
+	return dex, true
+}
+
+// Tokens returns every included token
+func (in Included) Tokens() []Token {
+	var tokens []Token
+	for _, resource := range in {
+		if resource.Type != ResourceTypeToken {
+			continue
+		}
+		if token, ok := in.Token(Relationship{Data: &ResourceIdentifier{ID: resource.ID, Type: resource.Type}}); ok {
+			tokens = append(tokens, *token)
+		}
+	}
+	return tokens
+}
+
+// Pools returns the included pools rels point to, skipping those that were not included
+func (in Included) Pools(rels RelationshipList) []Pool {
+	var pools []Pool
+	for i := range rels.Data {
+		resource, ok := in.find(&rels.Data[i])
+		if !ok {
+			continue
+		}
+
+		pool := Pool{ID: resource.ID, Type: resource.Type}
+		if err := json.Unmarshal(resource.Attributes, &pool.Attributes); err != nil {
+			continue
+		}
+		if relationships, err := json.Marshal(resource.Relationships); err == nil {
+			_ = json.Unmarshal(relationships, &pool.Relationships)
+		}
+		pools = append(pools, pool)
+	}
+	return pools
+}
+
+// merge returns in with the resources of other it does not contain yet
+func (in Included) merge(other Included) Included {
+	for _, resource := range other {
+		ref := ResourceIdentifier{ID: resource.ID, Type: resource.Type}
+		if _, ok := in.find(&ref); ok {
+			continue
+		}
+		in = append(in, resource)
+	}
+	return in
+}
@@ -0,0 +1,221 @@
+package onchain
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+)
+
+// PoolPageFunc fetches a page of a pool feed
+type PoolPageFunc func(ctx context.Context, page int) (*PoolsResponse, error)
+
+// PoolPager walks the pages of a pool feed until a page comes back empty or MaxPoolPages is reached:
+//
+//	pager := client.TopPoolsPages(&onchain.GetTopPoolsRequest{Network: "eth"})
+//	for pager.Next(ctx) {
+//		for _, pool := range pager.Page().Data {
+//			...
+//		}
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+type PoolPager struct {
+	fetch    PoolPageFunc
+	next     int
+	lastPage int
+	page     *PoolsResponse
+	err      error
+	done     bool
+}
+
+// NewPoolPager returns a pager fetching pages with fetch, starting at firstPage (1 when not positive)
+func NewPoolPager(fetch PoolPageFunc, firstPage int) *PoolPager {
+	if firstPage < 1 {
+		firstPage = 1
+	}
+	return &PoolPager{
+		fetch:    fetch,
+		next:     firstPage,
+		lastPage: MaxPoolPages,
+	}
+}
+
+// SetLastPage stops the pager after page lastPage, at most MaxPoolPages
+func (p *PoolPager) SetLastPage(lastPage int) {
+	if lastPage > MaxPoolPages {
+		lastPage = MaxPoolPages
+	}
+	p.lastPage = lastPage
+}
+
+// Next fetches the next page, returning false once every page was fetched or on error
+func (p *PoolPager) Next(ctx context.Context) bool {
+	if p.done || p.next > p.lastPage {
+		p.done = true
+		return false
+	}
+
+	page, err := p.fetch(ctx, p.next)
+	if err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+	if len(page.Data) == 0 {
+		p.done = true
+		return false
+	}
+
+	p.page = page
+	p.next++
+	return true
+}
+
+// Page returns the page fetched by the last call to Next
+func (p *PoolPager) Page() *PoolsResponse {
+	return p.page
+}
+
+// PageNumber returns the number of the page fetched by the last call to Next
+func (p *PoolPager) PageNumber() int {
+	return p.next - 1
+}
+
+// Err returns the error that stopped the pager, if any
+func (p *PoolPager) Err() error {
+	return p.err
+}
+
+// All fetches the remaining pages and merges them into one response,
+// dropping pools and included resources repeated across pages.
+// A base.ResponseMeta of ctx adds up the requests of all pages.
+func (p *PoolPager) All(ctx context.Context) (*PoolsResponse, error) {
+	ctx = base.WithAggregatedResponseMeta(ctx)
+	all := &PoolsResponse{}
+	pools := make(map[string]struct{})
+
+	for p.Next(ctx) {
+		for _, pool := range p.page.Data {
+			if _, ok := pools[pool.ID]; ok {
+				continue
+			}
+			pools[pool.ID] = struct{}{}
+			all.Data = append(all.Data, pool)
+		}
+		all.Included = all.Included.merge(p.page.Included)
+	}
+
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// OHLCVPageFunc fetches the candles of a pool before a time, the latest candles when before is zero
+type OHLCVPageFunc func(ctx context.Context, before time.Time) (*GetPoolOHLCVResponse, error)
+
+// OHLCVPager walks the candles of a pool backwards in time, moving before_timestamp
+// to the oldest candle of each page until a page brings no older candle:
+//
+//	pager := client.PoolOHLCVPages(&onchain.GetPoolOHLCVRequest{Network: "eth", Pool: address, Timeframe: onchain.TimeframeHour})
+//	pager.SetSince(time.Now().AddDate(0, -3, 0))
+//	candles, err := pager.All(ctx)
+type OHLCVPager struct {
+	fetch  OHLCVPageFunc
+	before time.Time
+	since  time.Time
+	page   *GetPoolOHLCVResponse
+	err    error
+	done   bool
+}
+
+// NewOHLCVPager returns a pager fetching candles with fetch, starting before before (the latest candles when zero)
+func NewOHLCVPager(fetch OHLCVPageFunc, before time.Time) *OHLCVPager {
+	return &OHLCVPager{
+		fetch:  fetch,
+		before: before,
+	}
+}
+
+// SetSince stops the pager once it reaches candles opened before since
+func (p *OHLCVPager) SetSince(since time.Time) {
+	p.since = since
+}
+
+// Next fetches the next older page, returning false once the history was walked or on error
+func (p *OHLCVPager) Next(ctx context.Context) bool {
+	if p.done {
+		return false
+	}
+
+	page, err := p.fetch(ctx, p.before)
+	if err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+
+	// Drop candles already returned, which the API may repeat at the page boundary,
+	// and candles older than since
+	var candles []Candle
+	for _, candle := range page.Candles() {
+		if !p.before.IsZero() && !candle.Time.Before(p.before) {
+			continue
+		}
+		if !p.since.IsZero() && candle.Time.Before(p.since) {
+			p.done = true
+			continue
+		}
+		candles = append(candles, candle)
+	}
+	if len(candles) == 0 {
+		p.done = true
+		return false
+	}
+
+	// Keep the pages latest first even when the API returns candles out of order
+	sort.SliceStable(candles, func(i, j int) bool {
+		return candles[i].Time.After(candles[j].Time)
+	})
+
+	page.Data.Attributes.OHLCVList = candles
+	p.page = page
+	p.before = candles[len(candles)-1].Time
+	return true
+}
+
+// Page returns the page fetched by the last call to Next
+func (p *OHLCVPager) Page() *GetPoolOHLCVResponse {
+	return p.page
+}
+
+// Candles returns the candles fetched by the last call to Next, latest first
+func (p *OHLCVPager) Candles() []Candle {
+	if p.page == nil {
+		return nil
+	}
+	return p.page.Candles()
+}
+
+// Err returns the error that stopped the pager, if any
+func (p *OHLCVPager) Err() error {
+	return p.err
+}
+
+// All fetches the remaining pages and returns their candles, latest first.
+// A base.ResponseMeta of ctx adds up the requests of all pages.
+func (p *OHLCVPager) All(ctx context.Context) ([]Candle, error) {
+	ctx = base.WithAggregatedResponseMeta(ctx)
+	var candles []Candle
+	for p.Next(ctx) {
+		candles = append(candles, p.Candles()...)
+	}
+
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return candles, nil
+}
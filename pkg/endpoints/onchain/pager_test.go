@@ -0,0 +1,394 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+)
+
+var testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// hourlyCandles returns n hourly candles from testStart, latest first
+func hourlyCandles(n int) []Candle {
+	candles := make([]Candle, n)
+	for i := range candles {
+		candles[i] = Candle{Time: testStart.Add(time.Duration(n-1-i) * time.Hour), Close: float64(n - 1 - i)}
+	}
+	return candles
+}
+
+// fakeOHLCV serves candles like the API, pageSize at a time, including the candle opened at before
+type fakeOHLCV struct {
+	candles  []Candle
+	pageSize int
+	// reverse returns each page oldest first
+	reverse bool
+	// failAt fails the fetch with this number, counting from 1
+	failAt int
+	calls  []time.Time
+}
+
+func (f *fakeOHLCV) fetch(ctx context.Context, before time.Time) (*GetPoolOHLCVResponse, error) {
+	f.calls = append(f.calls, before)
+	if len(f.calls) == f.failAt {
+		return nil, errors.New("upstream failed")
+	}
+
+	var page []Candle
+	for _, candle := range f.candles {
+		if !before.IsZero() && candle.Time.After(before) {
+			continue
+		}
+		if len(page) == f.pageSize {
+			break
+		}
+		page = append(page, candle)
+	}
+	if f.reverse {
+		for i, j := 0, len(page)-1; i < j; i, j = i+1, j-1 {
+			page[i], page[j] = page[j], page[i]
+		}
+	}
+
+	response := &GetPoolOHLCVResponse{}
+	response.Data.Attributes.OHLCVList = page
+	return response, nil
+}
+
+// candleTimes returns the hours of candles since testStart
+func candleTimes(candles []Candle) []int {
+	hours := make([]int, len(candles))
+	for i, candle := range candles {
+		hours[i] = int(candle.Time.Sub(testStart) / time.Hour)
+	}
+	return hours
+}
+
+func TestOHLCVPagerAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		fake      *fakeOHLCV
+		before    time.Time
+		since     time.Time
+		want      []int
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "repeated boundary candle",
+			fake:      &fakeOHLCV{candles: hourlyCandles(7), pageSize: 3},
+			want:      []int{6, 5, 4, 3, 2, 1, 0},
+			wantCalls: 4,
+		},
+		{
+			name:      "candles out of order",
+			fake:      &fakeOHLCV{candles: hourlyCandles(7), pageSize: 3, reverse: true},
+			want:      []int{6, 5, 4, 3, 2, 1, 0},
+			wantCalls: 4,
+		},
+		{
+			name:      "candles from before",
+			fake:      &fakeOHLCV{candles: hourlyCandles(7), pageSize: 3},
+			before:    testStart.Add(4 * time.Hour),
+			want:      []int{3, 2, 1, 0},
+			wantCalls: 3,
+		},
+		{
+			name:      "since cutoff mid-page",
+			fake:      &fakeOHLCV{candles: hourlyCandles(10), pageSize: 4},
+			since:     testStart.Add(4 * time.Hour),
+			want:      []int{9, 8, 7, 6, 5, 4},
+			wantCalls: 2,
+		},
+		{
+			name:      "since on a page boundary",
+			fake:      &fakeOHLCV{candles: hourlyCandles(10), pageSize: 4},
+			since:     testStart.Add(6 * time.Hour),
+			want:      []int{9, 8, 7, 6},
+			wantCalls: 2,
+		},
+		{
+			name:      "empty page",
+			fake:      &fakeOHLCV{pageSize: 3},
+			want:      []int{},
+			wantCalls: 1,
+		},
+		{
+			name:      "error",
+			fake:      &fakeOHLCV{candles: hourlyCandles(7), pageSize: 3, failAt: 2},
+			wantCalls: 2,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pager := NewOHLCVPager(tt.fake.fetch, tt.before)
+			pager.SetSince(tt.since)
+
+			candles, err := pager.All(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(tt.fake.calls) != tt.wantCalls {
+				t.Errorf("fetched %d pages, want %d (before: %v)", len(tt.fake.calls), tt.wantCalls, tt.fake.calls)
+			}
+			if tt.wantErr {
+				if candles != nil || !errors.Is(pager.Err(), err) {
+					t.Errorf("candles = %v, Err() = %v after an error", candles, pager.Err())
+				}
+				return
+			}
+
+			if got := candleTimes(candles); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("candles = %v, want %v", got, tt.want)
+			}
+			if pager.Next(context.Background()) {
+				t.Error("Next() = true after the last page")
+			}
+		})
+	}
+}
+
+func TestOHLCVPagerPages(t *testing.T) {
+	fake := &fakeOHLCV{candles: hourlyCandles(5), pageSize: 3, reverse: true}
+	pager := NewOHLCVPager(fake.fetch, time.Time{})
+
+	var pages [][]int
+	for pager.Next(context.Background()) {
+		if got := candleTimes(pager.Page().Candles()); fmt.Sprint(got) != fmt.Sprint(candleTimes(pager.Candles())) {
+			t.Errorf("Page().Candles() = %v, Candles() = %v", got, candleTimes(pager.Candles()))
+		}
+		pages = append(pages, candleTimes(pager.Candles()))
+	}
+
+	if want := "[[4 3 2] [1 0]]"; fmt.Sprint(pages) != want {
+		t.Errorf("pages = %v, want %s", pages, want)
+	}
+	if want := []time.Time{{}, testStart.Add(2 * time.Hour), testStart}; fmt.Sprint(fake.calls) != fmt.Sprint(want) {
+		t.Errorf("before = %v, want %v", fake.calls, want)
+	}
+}
+
+// testPool returns a pool with id and a dex relationship
+func testPool(id, dex string) Pool {
+	pool := Pool{ID: id, Type: "pool"}
+	pool.Relationships.Dex.Data = &ResourceIdentifier{ID: dex, Type: "dex"}
+	return pool
+}
+
+// fakePools serves pages of pools, counting fetched page numbers
+type fakePools struct {
+	pages map[int]*PoolsResponse
+	// failAt fails the fetch of this page
+	failAt int
+	calls  []int
+}
+
+func (f *fakePools) fetch(ctx context.Context, page int) (*PoolsResponse, error) {
+	f.calls = append(f.calls, page)
+	if page == f.failAt {
+		return nil, errors.New("upstream failed")
+	}
+	if response, ok := f.pages[page]; ok {
+		return response, nil
+	}
+	return &PoolsResponse{}, nil
+}
+
+// fullPools serves n pages of one pool each
+func fullPools(n int) *fakePools {
+	f := &fakePools{pages: make(map[int]*PoolsResponse)}
+	for page := 1; page <= n; page++ {
+		f.pages[page] = &PoolsResponse{Data: []Pool{testPool(fmt.Sprintf("eth_%d", page), "uniswap_v3")}}
+	}
+	return f
+}
+
+func TestPoolPagerAllDeduplicates(t *testing.T) {
+	uniswap := IncludedResource{ID: "uniswap_v3", Type: "dex"}
+	sushiswap := IncludedResource{ID: "sushiswap", Type: "dex"}
+	fake := &fakePools{pages: map[int]*PoolsResponse{
+		1: {Data: []Pool{testPool("eth_a", "uniswap_v3"), testPool("eth_b", "uniswap_v3")}, Included: Included{uniswap}},
+		// Pools move between pages as their ranking changes
+		2: {Data: []Pool{testPool("eth_b", "uniswap_v3"), testPool("eth_c", "sushiswap")}, Included: Included{uniswap, sushiswap}},
+	}}
+
+	all, err := NewPoolPager(fake.fetch, 1).All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, pool := range all.Data {
+		ids = append(ids, pool.ID)
+	}
+	if want := "[eth_a eth_b eth_c]"; fmt.Sprint(ids) != want {
+		t.Errorf("pools = %v, want %s", ids, want)
+	}
+	if len(all.Included) != 2 || all.Included[0].ID != "uniswap_v3" || all.Included[1].ID != "sushiswap" {
+		t.Errorf("included = %+v, want uniswap_v3 and sushiswap once", all.Included)
+	}
+	if want := "[1 2 3]"; fmt.Sprint(fake.calls) != want {
+		t.Errorf("fetched pages %v, want %s", fake.calls, want)
+	}
+}
+
+func TestPoolPagerPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		fake      *fakePools
+		firstPage int
+		lastPage  int
+		wantCalls string
+		wantErr   bool
+	}{
+		{
+			name:      "stops on an empty page",
+			fake:      fullPools(3),
+			firstPage: 1,
+			wantCalls: "[1 2 3 4]",
+		},
+		{
+			name:      "first page below 1",
+			fake:      fullPools(2),
+			firstPage: 0,
+			wantCalls: "[1 2 3]",
+		},
+		{
+			name:      "first page",
+			fake:      fullPools(5),
+			firstPage: 4,
+			wantCalls: "[4 5 6]",
+		},
+		{
+			name:      "last page",
+			fake:      fullPools(5),
+			firstPage: 1,
+			lastPage:  2,
+			wantCalls: "[1 2]",
+		},
+		{
+			name:      "last page capped at MaxPoolPages",
+			fake:      fullPools(MaxPoolPages + 5),
+			firstPage: 1,
+			lastPage:  MaxPoolPages + 5,
+			wantCalls: "[1 2 3 4 5 6 7 8 9 10]",
+		},
+		{
+			name:      "MaxPoolPages by default",
+			fake:      fullPools(MaxPoolPages + 5),
+			firstPage: 1,
+			wantCalls: "[1 2 3 4 5 6 7 8 9 10]",
+		},
+		{
+			name:      "error",
+			fake:      &fakePools{pages: fullPools(5).pages, failAt: 2},
+			firstPage: 1,
+			wantCalls: "[1 2]",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pager := NewPoolPager(tt.fake.fetch, tt.firstPage)
+			if tt.lastPage != 0 {
+				pager.SetLastPage(tt.lastPage)
+			}
+
+			for pager.Next(context.Background()) {
+				if want := fmt.Sprintf("eth_%d", pager.PageNumber()); pager.Page().Data[0].ID != want {
+					t.Errorf("page %d holds %s, want %s", pager.PageNumber(), pager.Page().Data[0].ID, want)
+				}
+			}
+			if (pager.Err() != nil) != tt.wantErr {
+				t.Errorf("Err() = %v, want error %v", pager.Err(), tt.wantErr)
+			}
+			if fmt.Sprint(tt.fake.calls) != tt.wantCalls {
+				t.Errorf("fetched pages %v, want %s", tt.fake.calls, tt.wantCalls)
+			}
+			if pager.Next(context.Background()) {
+				t.Error("Next() = true after the last page")
+			}
+		})
+	}
+}
+
+func TestPoolPagerAllError(t *testing.T) {
+	fake := &fakePools{pages: fullPools(3).pages, failAt: 3}
+
+	all, err := NewPoolPager(fake.fetch, 1).All(context.Background())
+	if err == nil || all != nil {
+		t.Errorf("All() = %v, %v, want an error", all, err)
+	}
+}
+
+// newEmptyPagesClient returns a client of a server answering every request with an empty page
+func newEmptyPagesClient(t *testing.T, calls *atomic.Int32) Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient(base.NewBaseClient(base.DefaultConfig(), base.WithBaseURL(server.URL), base.WithRetryPolicy(base.NoRetryPolicy())))
+}
+
+func TestPoolPagesNilRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		pager     func(client Client) *PoolPager
+		wantCalls int32
+		wantErr   bool
+	}{
+		{name: "trending pools", pager: func(client Client) *PoolPager { return client.TrendingPoolsPages(nil) }, wantCalls: 1},
+		{name: "network trending pools", pager: func(client Client) *PoolPager { return client.NetworkTrendingPoolsPages(nil) }, wantErr: true},
+		{name: "top pools", pager: func(client Client) *PoolPager { return client.TopPoolsPages(nil) }, wantErr: true},
+		{name: "dex top pools", pager: func(client Client) *PoolPager { return client.DexTopPoolsPages(nil) }, wantErr: true},
+		{name: "new pools", pager: func(client Client) *PoolPager { return client.NewPoolsPages(nil) }, wantCalls: 1},
+		{name: "network new pools", pager: func(client Client) *PoolPager { return client.NetworkNewPoolsPages(nil) }, wantErr: true},
+		{name: "token pools", pager: func(client Client) *PoolPager { return client.TokenPoolsPages(nil) }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			pager := tt.pager(newEmptyPagesClient(t, &calls))
+
+			// A nil request is the zero request, invalid when a path parameter is required
+			if pager.Next(context.Background()) {
+				t.Error("Next() = true on an empty feed")
+			}
+			if got := base.IsValidationError(pager.Err()); got != tt.wantErr {
+				t.Errorf("Err() = %v, want a validation error %v", pager.Err(), tt.wantErr)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("server received %d requests, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPoolOHLCVPagesNilRequest(t *testing.T) {
+	var calls atomic.Int32
+	pager := newEmptyPagesClient(t, &calls).PoolOHLCVPages(nil)
+
+	candles, err := pager.All(context.Background())
+	if !base.IsValidationError(err) || candles != nil {
+		t.Errorf("All() = %v, %v, want a validation error", candles, err)
+	}
+	if got := calls.Load(); got != 0 {
+		t.Errorf("server received %d requests, want 0", got)
+	}
+}
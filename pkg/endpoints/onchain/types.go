@@ -0,0 +1,885 @@
+package onchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+)
+
+// Network represents a blockchain network supported by the onchain API
+type Network struct {
+	// ID is the network ID used by the other onchain endpoints, e.g. "eth"
+	ID string `json:"id"`
+	// Type is the resource type, "network"
+	Type string `json:"type"`
+	// Attributes are the attributes of the network
+	Attributes NetworkAttributes `json:"attributes"`
+}
+
+// NetworkAttributes are the attributes of a network
+type NetworkAttributes struct {
+	// Name is the name of the network
+	Name string `json:"name"`
+	// CoingeckoAssetPlatformID is the matching asset platform ID of the /asset_platforms endpoint, if any
+	CoingeckoAssetPlatformID string `json:"coingecko_asset_platform_id,omitempty"`
+}
+
+// Dex represents a decentralized exchange
+type Dex struct {
+	// ID is the dex ID, e.g. "uniswap_v3"
+	ID string `json:"id"`
+	// Type is the resource type, "dex"
+	Type string `json:"type"`
+	// Attributes are the attributes of the dex
+	Attributes DexAttributes `json:"attributes"`
+}
+
+// DexAttributes are the attributes of a dex
+type DexAttributes struct {
+	// Name is the name of the dex
+	Name string `json:"name"`
+}
+
+// Token represents a token traded onchain
+type Token struct {
+	// ID is the token ID, the network ID and the token address, e.g. "eth_0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+	ID string `json:"id"`
+	// Type is the resource type, "token"
+	Type string `json:"type"`
+	// Attributes are the attributes of the token
+	Attributes TokenAttributes `json:"attributes"`
+	// Relationships link the token to its top pools, set by the token endpoints
+	Relationships TokenRelationships `json:"relationships,omitempty"`
+}
+
+// TokenAttributes are the attributes of a token
+type TokenAttributes struct {
+	// Address is the contract address of the token
+	Address string `json:"address"`
+	// Name is the name of the token
+	Name string `json:"name"`
+	// Symbol is the symbol of the token
+	Symbol string `json:"symbol"`
+	// Decimals is the number of decimals of the token
+	Decimals int `json:"decimals,omitempty"`
+	// ImageURL is the URL of the token's logo
+	ImageURL string `json:"image_url,omitempty"`
+	// CoingeckoCoinID is the matching coin ID of the /coins endpoints, if any
+	CoingeckoCoinID string `json:"coingecko_coin_id,omitempty"`
+	// TotalSupply is the total supply of the token, set by the token endpoints
+	TotalSupply Decimal `json:"total_supply,omitempty"`
+	// PriceUSD is the price of the token in USD, set by the token endpoints
+	PriceUSD Decimal `json:"price_usd,omitempty"`
+	// FdvUSD is the fully diluted valuation of the token in USD, set by the token endpoints
+	FdvUSD Decimal `json:"fdv_usd,omitempty"`
+	// TotalReserveInUSD is the liquidity of the pools of the token in USD, set by the token endpoints
+	TotalReserveInUSD Decimal `json:"total_reserve_in_usd,omitempty"`
+	// VolumeUSD is the trading volume in USD by timeframe, set by the token endpoints
+	VolumeUSD PoolTimeframes `json:"volume_usd,omitempty"`
+	// MarketCapUSD is the market cap of the token in USD, empty when unverified, set by the token endpoints
+	MarketCapUSD Decimal `json:"market_cap_usd,omitempty"`
+}
+
+// TokenRelationships link a token to its top pools
+type TokenRelationships struct {
+	// TopPools are the pools of the token with the most liquidity
+	TopPools RelationshipList `json:"top_pools"`
+}
+
+// Pool represents a liquidity pool
+type Pool struct {
+	// ID is the pool ID, the network ID and the pool address, e.g. "eth_0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640"
+	ID string `json:"id"`
+	// Type is the resource type, "pool"
+	Type string `json:"type"`
+	// Attributes are the attributes of the pool
+	Attributes PoolAttributes `json:"attributes"`
+	// Relationships link the pool to its tokens, dex and network
+	Relationships PoolRelationships `json:"relationships"`
+}
+
+// PoolAttributes are the attributes of a pool
+type PoolAttributes struct {
+	// Address is the contract address of the pool
+	Address string `json:"address"`
+	// Name is the name of the pool, e.g. "WETH / USDC 0.05%"
+	Name string `json:"name"`
+	// PoolCreatedAt is the time the pool was created
+	PoolCreatedAt time.Time `json:"pool_created_at"`
+	// BaseTokenPriceUSD is the price of the base token in USD
+	BaseTokenPriceUSD Decimal `json:"base_token_price_usd"`
+	// BaseTokenPriceNativeCurrency is the price of the base token in the native currency of the network
+	BaseTokenPriceNativeCurrency Decimal `json:"base_token_price_native_currency"`
+	// QuoteTokenPriceUSD is the price of the quote token in USD
+	QuoteTokenPriceUSD Decimal `json:"quote_token_price_usd"`
+	// QuoteTokenPriceNativeCurrency is the price of the quote token in the native currency of the network
+	QuoteTokenPriceNativeCurrency Decimal `json:"quote_token_price_native_currency"`
+	// BaseTokenPriceQuoteToken is the price of the base token in quote tokens
+	BaseTokenPriceQuoteToken Decimal `json:"base_token_price_quote_token"`
+	// QuoteTokenPriceBaseToken is the price of the quote token in base tokens
+	QuoteTokenPriceBaseToken Decimal `json:"quote_token_price_base_token"`
+	// FdvUSD is the fully diluted valuation of the base token in USD
+	FdvUSD Decimal `json:"fdv_usd"`
+	// MarketCapUSD is the market cap of the base token in USD, empty when unverified
+	MarketCapUSD Decimal `json:"market_cap_usd"`
+	// ReserveInUSD is the liquidity of the pool in USD
+	ReserveInUSD Decimal `json:"reserve_in_usd"`
+	// LockedLiquidityPercentage is the percentage of the liquidity that is locked
+	LockedLiquidityPercentage Decimal `json:"locked_liquidity_percentage,omitempty"`
+	// PriceChangePercentage is the price change percentage of the base token by timeframe
+	PriceChangePercentage PoolTimeframes `json:"price_change_percentage"`
+	// VolumeUSD is the trading volume in USD by timeframe
+	VolumeUSD PoolTimeframes `json:"volume_usd"`
+	// Transactions are the transaction counts by timeframe
+	Transactions PoolTransactionTimeframes `json:"transactions"`
+}
+
+// PoolTimeframes are values over the trailing timeframes of a pool
+type PoolTimeframes struct {
+	// M5 is the value over the last 5 minutes
+	M5 Decimal `json:"m5,omitempty"`
+	// M15 is the value over the last 15 minutes
+	M15 Decimal `json:"m15,omitempty"`
+	// M30 is the value over the last 30 minutes
+	M30 Decimal `json:"m30,omitempty"`
+	// H1 is the value over the last hour
+	H1 Decimal `json:"h1,omitempty"`
+	// H6 is the value over the last 6 hours
+	H6 Decimal `json:"h6,omitempty"`
+	// H24 is the value over the last 24 hours
+	H24 Decimal `json:"h24,omitempty"`
+}
+
+// PoolTransactions are the transaction counts of a pool over a timeframe
+type PoolTransactions struct {
+	// Buys is the number of buy transactions
+	Buys int `json:"buys"`
+	// Sells is the number of sell transactions
+	Sells int `json:"sells"`
+	// Buyers is the number of distinct buyers
+	Buyers int `json:"buyers"`
+	// Sellers is the number of distinct sellers
+	Sellers int `json:"sellers"`
+}
+
+// PoolTransactionTimeframes are the transaction counts over the trailing timeframes of a pool
+type PoolTransactionTimeframes struct {
+	// M5 are the transactions of the last 5 minutes
+	M5 PoolTransactions `json:"m5"`
+	// M15 are the transactions of the last 15 minutes
+	M15 PoolTransactions `json:"m15"`
+	// M30 are the transactions of the last 30 minutes
+	M30 PoolTransactions `json:"m30"`
+	// H1 are the transactions of the last hour
+	H1 PoolTransactions `json:"h1"`
+	// H6 are the transactions of the last 6 hours
+	H6 PoolTransactions `json:"h6"`
+	// H24 are the transactions of the last 24 hours
+	H24 PoolTransactions `json:"h24"`
+}
+
+// PoolRelationships link a pool to its tokens, dex and network
+type PoolRelationships struct {
+	// BaseToken is the base token of the pool
+	BaseToken Relationship `json:"base_token"`
+	// QuoteToken is the quote token of the pool
+	QuoteToken Relationship `json:"quote_token"`
+	// Dex is the dex of the pool
+	Dex Relationship `json:"dex"`
+	// Network is the network of the pool, set by multi-network endpoints
+	Network Relationship `json:"network"`
+}
+
+// GetNetworksRequest represents the request parameters for getting the supported networks
+type GetNetworksRequest struct {
+	// Page is the page number
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1"`
+}
+
+// GetNetworksResponse represents the response from the Networks API
+type GetNetworksResponse struct {
+	// Data are the networks
+	Data []Network `json:"data"`
+}
+
+// GetNetworkDexesRequest represents the request parameters for getting the dexes of a network
+type GetNetworkDexesRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Page is the page number
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1"`
+}
+
+// GetNetworkDexesResponse represents the response from the Network Dexes API
+type GetNetworkDexesResponse struct {
+	// Data are the dexes of the network
+	Data []Dex `json:"data"`
+}
+
+// GetPoolRequest represents the request parameters for getting a pool by address
+type GetPoolRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Address is the pool contract address
+	Address string `json:"address" validate:"required"`
+	// Include lists the related resources to return in Included: base_token, quote_token and dex
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=base_token quote_token dex"`
+}
+
+// GetPoolResponse represents the response from the Pool API
+type GetPoolResponse struct {
+	// Data is the pool
+	Data Pool `json:"data"`
+	// Included are the related resources requested with Include
+	Included Included `json:"included,omitempty"`
+}
+
+// BaseToken returns the base token of the pool, false when it was not included
+func (r *GetPoolResponse) BaseToken() (*Token, bool) {
+	return r.Included.Token(r.Data.Relationships.BaseToken)
+}
+
+// QuoteToken returns the quote token of the pool, false when it was not included
+func (r *GetPoolResponse) QuoteToken() (*Token, bool) {
+	return r.Included.Token(r.Data.Relationships.QuoteToken)
+}
+
+// Dex returns the dex of the pool, false when it was not included
+func (r *GetPoolResponse) Dex() (*Dex, bool) {
+	return r.Included.Dex(r.Data.Relationships.Dex)
+}
+
+// MaxMultiplePoolsAddresses is the maximum number of addresses of a multiple pools request
+const MaxMultiplePoolsAddresses = 30
+
+// GetMultiplePoolsRequest represents the request parameters for getting several pools by address
+type GetMultiplePoolsRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Addresses are the pool contract addresses, at most MaxMultiplePoolsAddresses
+	Addresses []string `json:"addresses" validate:"required,min=1,max=30,dive,required"`
+	// Include lists the related resources to return in Included: base_token, quote_token and dex
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=base_token quote_token dex"`
+}
+
+// GetMultiplePoolsResponse represents the response from the Multiple Pools API
+type GetMultiplePoolsResponse struct {
+	// Data are the pools found, in no particular order
+	Data []Pool `json:"data"`
+	// Included are the related resources requested with Include
+	Included Included `json:"included,omitempty"`
+}
+
+// SearchPoolsRequest represents the request parameters for searching pools
+type SearchPoolsRequest struct {
+	// Query is a pool address, token address or token symbol
+	Query string `json:"query" query:"query" validate:"required"`
+	// Network restricts the search to a network ID, e.g. "eth"
+	Network string `json:"network,omitempty" query:"network"`
+	// Include lists the related resources to return in Included: base_token, quote_token and dex
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=base_token quote_token dex"`
+	// Page is the page number
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1"`
+}
+
+// SearchPoolsResponse represents the response from the Search Pools API
+type SearchPoolsResponse struct {
+	// Data are the matching pools
+	Data []Pool `json:"data"`
+	// Included are the related resources requested with Include
+	Included Included `json:"included,omitempty"`
+}
+
+// Durations accepted by the trending pools endpoints
+const (
+	Duration5m  = "5m"
+	Duration1h  = "1h"
+	Duration6h  = "6h"
+	Duration24h = "24h"
+)
+
+// Sort orders accepted by the top pools endpoints
+const (
+	SortByTxCount   = "h24_tx_count_desc"
+	SortByVolumeUSD = "h24_volume_usd_desc"
+)
+
+// MaxPoolPages is the last page served by the pool feeds
+const MaxPoolPages = 10
+
+// PoolsResponse represents a page of a pool feed
+type PoolsResponse struct {
+	// Data are the pools of the page
+	Data []Pool `json:"data"`
+	// Included are the related resources requested with Include
+	Included Included `json:"included,omitempty"`
+}
+
+// GetTrendingPoolsRequest represents the request parameters for getting the trending pools of every network
+type GetTrendingPoolsRequest struct {
+	// Include lists the related resources to return in Included: base_token, quote_token and dex
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=base_token quote_token dex"`
+	// Page is the page number, at most MaxPoolPages
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1,max=10"`
+	// Duration is the trending window: 5m, 1h, 6h or 24h (default)
+	Duration string `json:"duration,omitempty" query:"duration" validate:"omitempty,oneof=5m 1h 6h 24h"`
+}
+
+// GetTrendingPoolsResponse represents the response from the Trending Pools API
+type GetTrendingPoolsResponse = PoolsResponse
+
+// GetNetworkTrendingPoolsRequest represents the request parameters for getting the trending pools of a network
+type GetNetworkTrendingPoolsRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Include lists the related resources to return in Included: base_token, quote_token and dex
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=base_token quote_token dex"`
+	// Page is the page number, at most MaxPoolPages
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1,max=10"`
+	// Duration is the trending window: 5m, 1h, 6h or 24h (default)
+	Duration string `json:"duration,omitempty" query:"duration" validate:"omitempty,oneof=5m 1h 6h 24h"`
+}
+
+// GetNetworkTrendingPoolsResponse represents the response from the Network Trending Pools API
+type GetNetworkTrendingPoolsResponse = PoolsResponse
+
+// GetTopPoolsRequest represents the request parameters for getting the top pools of a network
+type GetTopPoolsRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Include lists the related resources to return in Included: base_token, quote_token and dex
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=base_token quote_token dex"`
+	// Page is the page number, at most MaxPoolPages
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1,max=10"`
+	// Sort is the order of the pools: h24_tx_count_desc (default) or h24_volume_usd_desc
+	Sort string `json:"sort,omitempty" query:"sort" validate:"omitempty,oneof=h24_tx_count_desc h24_volume_usd_desc"`
+}
+
+// GetTopPoolsResponse represents the response from the Top Pools API
+type GetTopPoolsResponse = PoolsResponse
+
+// GetDexTopPoolsRequest represents the request parameters for getting the top pools of a dex
+type GetDexTopPoolsRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Dex is the dex ID, e.g. "uniswap_v3"
+	Dex string `json:"dex" validate:"required"`
+	// Include lists the related resources to return in Included: base_token, quote_token and dex
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=base_token quote_token dex"`
+	// Page is the page number, at most MaxPoolPages
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1,max=10"`
+	// Sort is the order of the pools: h24_tx_count_desc (default) or h24_volume_usd_desc
+	Sort string `json:"sort,omitempty" query:"sort" validate:"omitempty,oneof=h24_tx_count_desc h24_volume_usd_desc"`
+}
+
+// GetDexTopPoolsResponse represents the response from the Dex Top Pools API
+type GetDexTopPoolsResponse = PoolsResponse
+
+// GetNewPoolsRequest represents the request parameters for getting the latest pools of every network
+type GetNewPoolsRequest struct {
+	// Include lists the related resources to return in Included: base_token, quote_token and dex
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=base_token quote_token dex"`
+	// Page is the page number, at most MaxPoolPages
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1,max=10"`
+}
+
+// GetNewPoolsResponse represents the response from the New Pools API
+type GetNewPoolsResponse = PoolsResponse
+
+// GetNetworkNewPoolsRequest represents the request parameters for getting the latest pools of a network
+type GetNetworkNewPoolsRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Include lists the related resources to return in Included: base_token, quote_token and dex
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=base_token quote_token dex"`
+	// Page is the page number, at most MaxPoolPages
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1,max=10"`
+}
+
+// GetNetworkNewPoolsResponse represents the response from the Network New Pools API
+type GetNetworkNewPoolsResponse = PoolsResponse
+
+// Timeframes accepted by the pool OHLCV endpoint
+const (
+	TimeframeDay    = "day"
+	TimeframeHour   = "hour"
+	TimeframeMinute = "minute"
+)
+
+// Currencies of the prices of the pool OHLCV endpoint
+const (
+	CurrencyUSD   = "usd"
+	CurrencyToken = "token"
+)
+
+// Pool tokens accepted by the token parameter of the pool OHLCV and trades endpoints
+const (
+	TokenBase  = "base"
+	TokenQuote = "quote"
+)
+
+// MaxOHLCVLimit is the maximum number of candles of a pool OHLCV request
+const MaxOHLCVLimit = 1000
+
+// ohlcvAggregates are the aggregates accepted by each timeframe of the pool OHLCV endpoint
+var ohlcvAggregates = map[string][]int{
+	TimeframeDay:    {1},
+	TimeframeHour:   {1, 4, 12},
+	TimeframeMinute: {1, 5, 15},
+}
+
+// GetPoolOHLCVRequest represents the request parameters for getting the OHLCV candles of a pool
+type GetPoolOHLCVRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Pool is the pool contract address
+	Pool string `json:"pool" validate:"required"`
+	// Timeframe is the candle timeframe: day, hour or minute
+	Timeframe string `json:"timeframe" validate:"required,oneof=day hour minute"`
+	// Aggregate is the number of timeframes per candle: 1 for day, 1, 4 or 12 for hour, 1, 5 or 15 for minute
+	Aggregate int `json:"aggregate,omitempty" query:"aggregate" validate:"omitempty,min=1"`
+	// BeforeTimestamp returns the candles before this time, the latest candles when zero
+	BeforeTimestamp time.Time `json:"before_timestamp,omitempty" query:"before_timestamp"`
+	// Limit is the number of candles, 100 by default and at most MaxOHLCVLimit
+	Limit int `json:"limit,omitempty" query:"limit" validate:"omitempty,min=1,max=1000"`
+	// Currency is the currency of the prices: usd (default) or token, the other token of the pool
+	Currency string `json:"currency,omitempty" query:"currency" validate:"omitempty,oneof=usd token"`
+	// Token is the token whose prices are returned: base (default), quote or a token address of the pool
+	Token string `json:"token,omitempty" query:"token"`
+}
+
+// Candle is an OHLCV candle of a pool
+type Candle struct {
+	// Time is the opening time of the candle
+	Time time.Time
+	// Open is the opening price
+	Open float64
+	// High is the highest price
+	High float64
+	// Low is the lowest price
+	Low float64
+	// Close is the closing price
+	Close float64
+	// Volume is the trading volume in USD
+	Volume float64
+}
+
+// UnmarshalJSON decodes a candle sent as [timestamp, open, high, low, close, volume]
+func (c *Candle) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if len(values) != 6 {
+		return fmt.Errorf("invalid candle: expected 6 values, got %d", len(values))
+	}
+
+	*c = Candle{
+		Time:   time.Unix(int64(values[0]), 0).UTC(),
+		Open:   values[1],
+		High:   values[2],
+		Low:    values[3],
+		Close:  values[4],
+		Volume: values[5],
+	}
+	return nil
+}
+
+// MarshalJSON encodes the candle as [timestamp, open, high, low, close, volume]
+func (c Candle) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float64{float64(c.Time.Unix()), c.Open, c.High, c.Low, c.Close, c.Volume})
+}
+
+// OHLCVToken describes a token of the pool OHLCV response
+type OHLCVToken struct {
+	// Address is the contract address of the token
+	Address string `json:"address"`
+	// Name is the name of the token
+	Name string `json:"name"`
+	// Symbol is the symbol of the token
+	Symbol string `json:"symbol"`
+	// CoingeckoCoinID is the matching coin ID of the /coins endpoints, if any
+	CoingeckoCoinID string `json:"coingecko_coin_id,omitempty"`
+}
+
+// OHLCVMeta describes the tokens of the pool OHLCV response
+type OHLCVMeta struct {
+	// Base is the base token of the pool
+	Base OHLCVToken `json:"base"`
+	// Quote is the quote token of the pool
+	Quote OHLCVToken `json:"quote"`
+}
+
+// OHLCVAttributes are the attributes of the pool OHLCV response
+type OHLCVAttributes struct {
+	// OHLCVList are the candles, latest first
+	OHLCVList []Candle `json:"ohlcv_list"`
+}
+
+// OHLCVData is the primary data of the pool OHLCV response
+type OHLCVData struct {
+	// ID is the identifier of the response
+	ID string `json:"id"`
+	// Type is the resource type, "ohlcv_request_response"
+	Type string `json:"type"`
+	// Attributes hold the candles
+	Attributes OHLCVAttributes `json:"attributes"`
+}
+
+// GetPoolOHLCVResponse represents the response from the Pool OHLCV API
+type GetPoolOHLCVResponse struct {
+	// Data holds the candles
+	Data OHLCVData `json:"data"`
+	// Meta describes the tokens of the pool
+	Meta OHLCVMeta `json:"meta"`
+}
+
+// Candles returns the candles, latest first
+func (r *GetPoolOHLCVResponse) Candles() []Candle {
+	return r.Data.Attributes.OHLCVList
+}
+
+// Trade kinds
+const (
+	TradeKindBuy  = "buy"
+	TradeKindSell = "sell"
+)
+
+// GetPoolTradesRequest represents the request parameters for getting the trades of a pool of the last 24 hours
+type GetPoolTradesRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Pool is the pool contract address
+	Pool string `json:"pool" validate:"required"`
+	// TradeVolumeInUSDGreaterThan only returns trades of a larger volume in USD
+	TradeVolumeInUSDGreaterThan float64 `json:"trade_volume_in_usd_greater_than,omitempty" query:"trade_volume_in_usd_greater_than" validate:"omitempty,min=0"`
+	// Token is the token the trades are reported for: base (default), quote or a token address of the pool
+	Token string `json:"token,omitempty" query:"token"`
+}
+
+// Trade represents a trade of a pool
+type Trade struct {
+	// ID is the trade ID
+	ID string `json:"id"`
+	// Type is the resource type, "trade"
+	Type string `json:"type"`
+	// Attributes are the attributes of the trade
+	Attributes TradeAttributes `json:"attributes"`
+}
+
+// TradeAttributes are the attributes of a trade
+type TradeAttributes struct {
+	// BlockNumber is the number of the block of the trade
+	BlockNumber int64 `json:"block_number"`
+	// BlockTimestamp is the time of the block of the trade
+	BlockTimestamp time.Time `json:"block_timestamp"`
+	// TxHash is the hash of the transaction of the trade
+	TxHash string `json:"tx_hash"`
+	// TxFromAddress is the address that sent the transaction
+	TxFromAddress string `json:"tx_from_address"`
+	// Kind is the side of the trade: buy or sell
+	Kind string `json:"kind"`
+	// FromTokenAddress is the address of the token sold
+	FromTokenAddress string `json:"from_token_address"`
+	// ToTokenAddress is the address of the token bought
+	ToTokenAddress string `json:"to_token_address"`
+	// FromTokenAmount is the amount of the token sold
+	FromTokenAmount Decimal `json:"from_token_amount"`
+	// ToTokenAmount is the amount of the token bought
+	ToTokenAmount Decimal `json:"to_token_amount"`
+	// PriceFromInCurrencyToken is the price of the token sold in the other token of the pool
+	PriceFromInCurrencyToken Decimal `json:"price_from_in_currency_token"`
+	// PriceToInCurrencyToken is the price of the token bought in the other token of the pool
+	PriceToInCurrencyToken Decimal `json:"price_to_in_currency_token"`
+	// PriceFromInUSD is the price of the token sold in USD
+	PriceFromInUSD Decimal `json:"price_from_in_usd"`
+	// PriceToInUSD is the price of the token bought in USD
+	PriceToInUSD Decimal `json:"price_to_in_usd"`
+	// VolumeInUSD is the volume of the trade in USD
+	VolumeInUSD Decimal `json:"volume_in_usd"`
+}
+
+// GetPoolTradesResponse represents the response from the Pool Trades API
+type GetPoolTradesResponse struct {
+	// Data are the trades, latest first
+	Data []Trade `json:"data"`
+}
+
+// Sort orders accepted by the token pools endpoint, besides SortByTxCount and SortByVolumeUSD
+const (
+	SortByVolumeUSDLiquidity = "h24_volume_usd_liquidity_desc"
+)
+
+// GetTokenRequest represents the request parameters for getting a token by address
+type GetTokenRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Address is the token contract address
+	Address string `json:"address" validate:"required"`
+	// Include lists the related resources to return in Included: top_pools
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=top_pools"`
+}
+
+// GetTokenResponse represents the response from the Token API
+type GetTokenResponse struct {
+	// Data is the token
+	Data Token `json:"data"`
+	// Included are the related resources requested with Include
+	Included Included `json:"included,omitempty"`
+}
+
+// TopPools returns the top pools of the token, empty when they were not included
+func (r *GetTokenResponse) TopPools() []Pool {
+	return r.Included.Pools(r.Data.Relationships.TopPools)
+}
+
+// MaxMultipleTokensAddresses is the maximum number of addresses the API accepts in a multiple tokens request,
+// longer lists are split into several requests
+const MaxMultipleTokensAddresses = 30
+
+// GetMultipleTokensRequest represents the request parameters for getting several tokens by address
+type GetMultipleTokensRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Addresses are the token contract addresses, sent MaxMultipleTokensAddresses at a time
+	Addresses []string `json:"addresses" validate:"required,min=1,dive,required"`
+	// Include lists the related resources to return in Included: top_pools
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=top_pools"`
+}
+
+// GetMultipleTokensResponse represents the response from the Multiple Tokens API
+type GetMultipleTokensResponse struct {
+	// Data are the tokens found, in no particular order
+	Data []Token `json:"data"`
+	// Included are the related resources requested with Include
+	Included Included `json:"included,omitempty"`
+}
+
+// GetTokenInfoRequest represents the request parameters for getting the metadata of a token
+type GetTokenInfoRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Address is the token contract address
+	Address string `json:"address" validate:"required"`
+}
+
+// TokenInfo represents the metadata of a token
+type TokenInfo struct {
+	// ID is the token ID, the network ID and the token address
+	ID string `json:"id"`
+	// Type is the resource type, "token"
+	Type string `json:"type"`
+	// Attributes are the metadata of the token
+	Attributes TokenInfoAttributes `json:"attributes"`
+}
+
+// TokenInfoAttributes are the metadata of a token
+type TokenInfoAttributes struct {
+	// Address is the contract address of the token
+	Address string `json:"address"`
+	// Name is the name of the token
+	Name string `json:"name"`
+	// Symbol is the symbol of the token
+	Symbol string `json:"symbol"`
+	// ImageURL is the URL of the token's logo
+	ImageURL string `json:"image_url,omitempty"`
+	// CoingeckoCoinID is the matching coin ID of the /coins endpoints, if any
+	CoingeckoCoinID string `json:"coingecko_coin_id,omitempty"`
+	// Websites are the websites of the token
+	Websites []string `json:"websites,omitempty"`
+	// Description is the description of the token
+	Description string `json:"description,omitempty"`
+	// GtScore is the GeckoTerminal trust score of the token, from 0 to 100
+	GtScore float64 `json:"gt_score,omitempty"`
+	// DiscordURL is the URL of the token's Discord server
+	DiscordURL string `json:"discord_url,omitempty"`
+	// TelegramHandle is the Telegram handle of the token
+	TelegramHandle string `json:"telegram_handle,omitempty"`
+	// TwitterHandle is the Twitter handle of the token
+	TwitterHandle string `json:"twitter_handle,omitempty"`
+	// Categories are the names of the categories of the token
+	Categories []string `json:"categories,omitempty"`
+	// GtCategoryIDs are the IDs of the categories of the token
+	GtCategoryIDs []string `json:"gt_category_ids,omitempty"`
+}
+
+// GetTokenInfoResponse represents the response from the Token Info API
+type GetTokenInfoResponse struct {
+	// Data is the token metadata
+	Data TokenInfo `json:"data"`
+}
+
+// GetTokenPoolsRequest represents the request parameters for getting the top pools of a token
+type GetTokenPoolsRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Address is the token contract address
+	Address string `json:"address" validate:"required"`
+	// Include lists the related resources to return in Included: base_token, quote_token and dex
+	Include []string `json:"include,omitempty" query:"include" validate:"omitempty,dive,oneof=base_token quote_token dex"`
+	// Page is the page number, at most MaxPoolPages
+	Page int `json:"page,omitempty" query:"page" validate:"omitempty,min=1,max=10"`
+	// Sort is the order of the pools: h24_volume_usd_liquidity_desc (default), h24_tx_count_desc or h24_volume_usd_desc
+	Sort string `json:"sort,omitempty" query:"sort" validate:"omitempty,oneof=h24_volume_usd_liquidity_desc h24_tx_count_desc h24_volume_usd_desc"`
+}
+
+// GetTokenPoolsResponse represents the response from the Token Pools API
+type GetTokenPoolsResponse = PoolsResponse
+
+// MaxTokenPriceAddresses is the maximum number of addresses the API accepts in a token price request,
+// longer lists are split into several requests
+const MaxTokenPriceAddresses = 30
+
+// GetTokenPriceRequest represents the request parameters for getting token prices by address on a network
+type GetTokenPriceRequest struct {
+	// Network is the network ID, e.g. "eth"
+	Network string `json:"network" validate:"required"`
+	// Addresses are the token contract addresses, sent MaxTokenPriceAddresses at a time
+	Addresses []string `json:"addresses" validate:"required,min=1,dive,required"`
+	// IncludeMarketCap indicates whether to include market cap data
+	IncludeMarketCap bool `json:"include_market_cap,omitempty" query:"include_market_cap"`
+	// Include24HrVol indicates whether to include 24hr volume data
+	Include24HrVol bool `json:"include_24hr_vol,omitempty" query:"include_24hr_vol"`
+	// Include24HrPriceChange indicates whether to include 24hr price change data
+	Include24HrPriceChange bool `json:"include_24hr_price_change,omitempty" query:"include_24hr_price_change"`
+	// IncludeTotalReserveInUSD indicates whether to include the liquidity of the pools of each token
+	IncludeTotalReserveInUSD bool `json:"include_total_reserve_in_usd,omitempty" query:"include_total_reserve_in_usd"`
+}
+
+// TokenPriceAttributes are the prices and market data of the requested tokens, keyed by token address
+type TokenPriceAttributes struct {
+	// TokenPrices are the prices in USD
+	TokenPrices map[string]Decimal `json:"token_prices"`
+	// MarketCapUSD are the market caps in USD, requested with IncludeMarketCap
+	MarketCapUSD map[string]Decimal `json:"market_cap_usd,omitempty"`
+	// H24VolumeUSD are the 24h volumes in USD, requested with Include24HrVol
+	H24VolumeUSD map[string]Decimal `json:"h24_volume_usd,omitempty"`
+	// H24PriceChangePercentage are the 24h price changes, requested with Include24HrPriceChange
+	H24PriceChangePercentage map[string]Decimal `json:"h24_price_change_percentage,omitempty"`
+	// TotalReserveInUSD are the liquidities in USD, requested with IncludeTotalReserveInUSD
+	TotalReserveInUSD map[string]Decimal `json:"total_reserve_in_usd,omitempty"`
+}
+
+// TokenPriceData is the primary data of the token price response
+type TokenPriceData struct {
+	// ID is the identifier of the response
+	ID string `json:"id"`
+	// Type is the resource type, "simple_token_price"
+	Type string `json:"type"`
+	// Attributes are the prices and market data
+	Attributes TokenPriceAttributes `json:"attributes"`
+}
+
+// GetTokenPriceResponse represents the response from the Onchain Simple Token Price API
+type GetTokenPriceResponse struct {
+	// Data holds the prices
+	Data TokenPriceData `json:"data"`
+}
+
+// Price returns the price in USD of the token at address, false when the API returned none
+func (r *GetTokenPriceResponse) Price(address string) (Decimal, bool) {
+	if price, ok := r.Data.Attributes.TokenPrices[address]; ok {
+		return price, true
+	}
+	price, ok := r.Data.Attributes.TokenPrices[strings.ToLower(address)]
+	return price, ok
+}
+
+func (r *GetNetworksRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetNetworkDexesRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetPoolRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetMultiplePoolsRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *SearchPoolsRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetTrendingPoolsRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetNetworkTrendingPoolsRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetTopPoolsRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetDexTopPoolsRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetNewPoolsRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetNetworkNewPoolsRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetPoolOHLCVRequest) Validate() error {
+	if err := base.Validate(r); err != nil {
+		return err
+	}
+
+	aggregates := ohlcvAggregates[r.Timeframe]
+	if r.Aggregate != 0 && !slices.Contains(aggregates, r.Aggregate) {
+		allowed := make([]string, len(aggregates))
+		for i, aggregate := range aggregates {
+			allowed[i] = strconv.Itoa(aggregate)
+		}
+		return &base.ValidationError{
+			Request: "GetPoolOHLCVRequest",
+			Fields: []base.FieldError{{
+				Field:   "aggregate",
+				Rule:    "aggregate",
+				Param:   r.Timeframe,
+				Value:   r.Aggregate,
+				Message: "must be one of: " + strings.Join(allowed, ", ") + " for the " + r.Timeframe + " timeframe",
+			}},
+		}
+	}
+
+	return nil
+}
+
+func (r *GetPoolTradesRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetTokenRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetMultipleTokensRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetTokenInfoRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetTokenPoolsRequest) Validate() error {
+	return base.Validate(r)
+}
+
+func (r *GetTokenPriceRequest) Validate() error {
+	return base.Validate(r)
+}
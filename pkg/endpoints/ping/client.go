@@ -1,6 +1,8 @@
 package ping
 
 import (
+	"context"
+
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 )
 
@@ -10,6 +12,7 @@ const (
 
 type Client interface {
 	Ping() (*PingResponse, error)
+	PingWithContext(ctx context.Context) (*PingResponse, error)
 }
 
 type ClientImpl struct {
@@ -23,9 +26,17 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) Ping() (*PingResponse, error) {
+	return c.PingWithContext(context.Background())
+}
+
+func (c *ClientImpl) PingWithContext(ctx context.Context) (*PingResponse, error) {
 	var response PingResponse
 
-	if err := c.baseClient.Get(PingEndpoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "ping.Ping",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, PingEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
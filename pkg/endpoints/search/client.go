@@ -1,6 +1,7 @@
 package search
 
 import (
+	"context"
 	"fmt"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 )
@@ -11,6 +12,7 @@ const (
 
 type Client interface {
 	Search(request *SearchRequest) (*SearchResponse, error)
+	SearchWithContext(ctx context.Context, request *SearchRequest) (*SearchResponse, error)
 }
 
 type ClientImpl struct {
@@ -24,19 +26,27 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) Search(request *SearchRequest) (*SearchResponse, error) {
+	return c.SearchWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) SearchWithContext(ctx context.Context, request *SearchRequest) (*SearchResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response SearchResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"query": request.Query,
-		},
+		Operation:   "search.Search",
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(SearchEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, SearchEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
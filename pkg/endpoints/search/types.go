@@ -1,11 +1,11 @@
 package search
 
-import "github.com/go-playground/validator/v10"
+import "github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 
 // SearchRequest represents the request parameters for search
 type SearchRequest struct {
 	// Query is the search query
-	Query string `json:"query" validate:"required"`
+	Query string `json:"query" query:"query" validate:"required"`
 }
 
 // SearchResponse represents the response from the Search API
@@ -72,6 +72,5 @@ type NFT struct {
 
 // Validate validates the request structs
 func (r *SearchRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
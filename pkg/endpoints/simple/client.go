@@ -1,10 +1,10 @@
 package simple
 
 import (
+	"context"
 	"fmt"
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
-	"strconv"
-	"strings"
+	"time"
 )
 
 const (
@@ -13,10 +13,20 @@ const (
 	GetSupportedCurrenciesEndpoint     = "/simple/supported_vs_currencies"
 )
 
+// Default cache TTLs of endpoints whose data rarely changes
+func init() {
+	base.RegisterCacheTTL(GetCoinPriceByIDsEndpoint, 5*time.Minute)
+	base.RegisterCacheTTL(GetCoinPriceByTokenAddressEndpoint, 5*time.Minute)
+	base.RegisterCacheTTL(GetSupportedCurrenciesEndpoint, 24*time.Hour)
+}
+
 type Client interface {
 	GetCoinPriceByIDs(request *GetCoinPriceByIDsRequest) (*GetCoinPriceByIDsResponse, error)
+	GetCoinPriceByIDsWithContext(ctx context.Context, request *GetCoinPriceByIDsRequest) (*GetCoinPriceByIDsResponse, error)
 	GetCoinPriceByTokenAddress(request *GetCoinPriceByTokenAddressRequest) (*GetCoinPriceByTokenAddressResponse, error)
+	GetCoinPriceByTokenAddressWithContext(ctx context.Context, request *GetCoinPriceByTokenAddressRequest) (*GetCoinPriceByTokenAddressResponse, error)
 	GetSupportedCurrencies() (*GetSupportedCurrenciesResponse, error)
+	GetSupportedCurrenciesWithContext(ctx context.Context) (*GetSupportedCurrenciesResponse, error)
 }
 
 type ClientImpl struct {
@@ -30,25 +40,27 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) GetCoinPriceByIDs(request *GetCoinPriceByIDsRequest) (*GetCoinPriceByIDsResponse, error) {
+	return c.GetCoinPriceByIDsWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinPriceByIDsWithContext(ctx context.Context, request *GetCoinPriceByIDsRequest) (*GetCoinPriceByIDsResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinPriceByIDsResponse
 
 	opts := &base.RequestOptions{
-		QueryParams: map[string]string{
-			"ids":                     strings.Join(request.CoinIDs, ","),
-			"vs_currencies":           strings.Join(request.VsCurrencies, ","),
-			"include_market_cap":      strconv.FormatBool(request.IncludeMarketCap),
-			"include_24hr_vol":        strconv.FormatBool(request.Include24HrVol),
-			"include_24hr_change":     strconv.FormatBool(request.Include24HrChange),
-			"include_last_updated_at": strconv.FormatBool(request.IncludeLastUpdatedAt),
-			"precision":               request.Precision,
-		},
+		Operation:   "simple.GetCoinPriceByIDs",
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetCoinPriceByIDsEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinPriceByIDsEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -56,28 +68,30 @@ func (c *ClientImpl) GetCoinPriceByIDs(request *GetCoinPriceByIDsRequest) (*GetC
 }
 
 func (c *ClientImpl) GetCoinPriceByTokenAddress(request *GetCoinPriceByTokenAddressRequest) (*GetCoinPriceByTokenAddressResponse, error) {
+	return c.GetCoinPriceByTokenAddressWithContext(context.Background(), request)
+}
+
+func (c *ClientImpl) GetCoinPriceByTokenAddressWithContext(ctx context.Context, request *GetCoinPriceByTokenAddressRequest) (*GetCoinPriceByTokenAddressResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	query, err := base.EncodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
 	var response GetCoinPriceByTokenAddressResponse
 
 	opts := &base.RequestOptions{
+		Operation: "simple.GetCoinPriceByTokenAddress",
 		PathParams: map[string]string{
 			"id": request.ID,
 		},
-		QueryParams: map[string]string{
-			"contract_addresses":      strings.Join(request.ContractAddresses, ","),
-			"vs_currencies":           strings.Join(request.VsCurrencies, ","),
-			"include_market_cap":      strconv.FormatBool(request.IncludeMarketCap),
-			"include_24hr_vol":        strconv.FormatBool(request.Include24HrVol),
-			"include_24hr_change":     strconv.FormatBool(request.Include24HrChange),
-			"include_last_updated_at": strconv.FormatBool(request.IncludeLastUpdatedAt),
-			"precision":               request.Precision,
-		},
+		QueryParams: query,
 	}
 
-	if err := c.baseClient.Get(GetCoinPriceByTokenAddressEndpoint, opts, &response); err != nil {
+	if err := c.baseClient.GetWithContext(ctx, GetCoinPriceByTokenAddressEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -85,9 +99,17 @@ func (c *ClientImpl) GetCoinPriceByTokenAddress(request *GetCoinPriceByTokenAddr
 }
 
 func (c *ClientImpl) GetSupportedCurrencies() (*GetSupportedCurrenciesResponse, error) {
+	return c.GetSupportedCurrenciesWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetSupportedCurrenciesWithContext(ctx context.Context) (*GetSupportedCurrenciesResponse, error) {
 	var response GetSupportedCurrenciesResponse
 
-	if err := c.baseClient.Get(GetSupportedCurrenciesEndpoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "simple.GetSupportedCurrencies",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetSupportedCurrenciesEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
@@ -1,23 +1,23 @@
 package simple
 
-import "github.com/go-playground/validator/v10"
+import "github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 
 // GetCoinPriceByIDsRequest represents the request parameters for getting coin prices by IDs
 type GetCoinPriceByIDsRequest struct {
 	// CoinIDs is a list of coin IDs to get prices for
-	CoinIDs []string `json:"ids" validate:"required,min=1"`
+	CoinIDs []string `json:"ids" query:"ids" validate:"required,min=1"`
 	// VsCurrencies is a list of target currencies to get prices in (e.g., ["usd", "eur"])
-	VsCurrencies []string `json:"vs_currencies" validate:"required,min=1"`
+	VsCurrencies []string `json:"vs_currencies" query:"vs_currencies" validate:"required,min=1,dive,vs_currency"`
 	// IncludeMarketCap indicates whether to include market cap data
-	IncludeMarketCap bool `json:"include_market_cap,omitempty"`
+	IncludeMarketCap bool `json:"include_market_cap,omitempty" query:"include_market_cap"`
 	// Include24HrVol indicates whether to include 24hr volume data
-	Include24HrVol bool `json:"include_24hr_vol,omitempty"`
+	Include24HrVol bool `json:"include_24hr_vol,omitempty" query:"include_24hr_vol"`
 	// Include24HrChange indicates whether to include 24hr price change data
-	Include24HrChange bool `json:"include_24hr_change,omitempty"`
+	Include24HrChange bool `json:"include_24hr_change,omitempty" query:"include_24hr_change"`
 	// IncludeLastUpdatedAt indicates whether to include last updated timestamp
-	IncludeLastUpdatedAt bool `json:"include_last_updated_at,omitempty"`
+	IncludeLastUpdatedAt bool `json:"include_last_updated_at,omitempty" query:"include_last_updated_at"`
 	// Precision indicates the number of decimal places to use for price data
-	Precision string `json:"precision,omitempty"`
+	Precision string `json:"precision,omitempty" query:"precision"`
 }
 
 // CoinPrice represents the price data for a single coin
@@ -39,22 +39,22 @@ type GetCoinPriceByIDsResponse map[string]CoinPrice
 
 // GetCoinPriceByTokenAddressRequest represents the request parameters for getting coin price by token address
 type GetCoinPriceByTokenAddressRequest struct {
-	// ID is the token contract address
+	// ID is the asset platform ID, e.g. ethereum
 	ID string `json:"id" validate:"required"`
 	// ContractAddresses is a list of token contract addresses
-	ContractAddresses []string `json:"contract_addresses" validate:"required,min=1"`
+	ContractAddresses []string `json:"contract_addresses" query:"contract_addresses" validate:"required,min=1,dive,address=ID"`
 	// VsCurrencies is a list of target currencies to get prices in (e.g., ["usd", "eur"])
-	VsCurrencies []string `json:"vs_currencies" validate:"required,min=1"`
+	VsCurrencies []string `json:"vs_currencies" query:"vs_currencies" validate:"required,min=1,dive,vs_currency"`
 	// IncludeMarketCap indicates whether to include market cap data
-	IncludeMarketCap bool `json:"include_market_cap,omitempty"`
+	IncludeMarketCap bool `json:"include_market_cap,omitempty" query:"include_market_cap"`
 	// Include24HrVol indicates whether to include 24hr volume data
-	Include24HrVol bool `json:"include_24hr_vol,omitempty"`
+	Include24HrVol bool `json:"include_24hr_vol,omitempty" query:"include_24hr_vol"`
 	// Include24HrChange indicates whether to include 24hr price change data
-	Include24HrChange bool `json:"include_24hr_change,omitempty"`
+	Include24HrChange bool `json:"include_24hr_change,omitempty" query:"include_24hr_change"`
 	// IncludeLastUpdatedAt indicates whether to include last updated timestamp
-	IncludeLastUpdatedAt bool `json:"include_last_updated_at,omitempty"`
+	IncludeLastUpdatedAt bool `json:"include_last_updated_at,omitempty" query:"include_last_updated_at"`
 	// Precision indicates the number of decimal places to use for price data
-	Precision string `json:"precision,omitempty"`
+	Precision string `json:"precision,omitempty" query:"precision"`
 }
 
 // CoinPriceByTokenAddress represents the price data for a single token
@@ -79,11 +79,9 @@ type GetSupportedCurrenciesResponse []string
 
 // Validate validates the request structs
 func (r *GetCoinPriceByIDsRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
 
 func (r *GetCoinPriceByTokenAddressRequest) Validate() error {
-	validate := validator.New()
-	return validate.Struct(r)
+	return base.Validate(r)
 }
@@ -1,6 +1,8 @@
 package trending
 
 import (
+	"context"
+
 	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
 )
 
@@ -10,6 +12,7 @@ const (
 
 type Client interface {
 	GetTrending() (*TrendingResponse, error)
+	GetTrendingWithContext(ctx context.Context) (*TrendingResponse, error)
 }
 
 type ClientImpl struct {
@@ -23,9 +26,17 @@ func NewClient(baseClient *base.BaseClient) Client {
 }
 
 func (c *ClientImpl) GetTrending() (*TrendingResponse, error) {
+	return c.GetTrendingWithContext(context.Background())
+}
+
+func (c *ClientImpl) GetTrendingWithContext(ctx context.Context) (*TrendingResponse, error) {
 	var response TrendingResponse
 
-	if err := c.baseClient.Get(GetTrendingEndpoint, nil, &response); err != nil {
+	opts := &base.RequestOptions{
+		Operation: "trending.GetTrending",
+	}
+
+	if err := c.baseClient.GetWithContext(ctx, GetTrendingEndpoint, opts, &response); err != nil {
 		return nil, err
 	}
 
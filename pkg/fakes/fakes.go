@@ -0,0 +1,268 @@
+// Package fakes provides an in-memory pkg.Client for unit tests.
+//
+// A fake Client is a real client whose requests never leave the process:
+// every call is recorded and answered with a canned response, an injected
+// error, or the default fixture of its endpoint. Calls are identified by
+// operation name, "<package>.<Method>", e.g. "coins.GetCoinsList".
+//
+//	fake := fakes.NewClient()
+//	fake.SetResponse("simple.GetCoinPriceByIDs", simple.GetCoinPriceByIDsResponse{
+//		"bitcoin": {USD: 67000},
+//	})
+//	fake.SetError("coins.GetCoinDataByID", fakes.HTTPError(http.StatusNotFound))
+//
+//	service := NewPriceService(fake) // accepts a pkg.Client
+//	...
+//	if fake.CallCount("simple.GetCoinPriceByIDs") != 1 { ... }
+//
+// Sub-clients such as fake.CoinsAPI() satisfy the endpoint package interfaces.
+// Requests are still validated, so invalid requests fail as they would against the API.
+package fakes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ipangpang/coingecko-v3/pkg"
+	"github.com/ipangpang/coingecko-v3/pkg/coingeckotest"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+)
+
+// ErrNoResponse is returned for calls without canned response when fixtures are disabled
+var ErrNoResponse = errors.New("fakes: no response configured")
+
+// Call is a recorded call
+type Call struct {
+	// Operation is the logical name of the endpoint method, e.g. "coins.GetCoinsList"
+	Operation string
+	// Method is the HTTP method
+	Method string
+	// Path is the endpoint path template, e.g. "/coins/{id}"
+	Path string
+	// PathParams are the values substituted into Path
+	PathParams map[string]string
+	// QueryParams are the query parameters
+	QueryParams map[string]string
+	// Time is the time the call was made
+	Time time.Time
+}
+
+// Responder computes the response of a call; the response is encoded to JSON,
+// unless it is a []byte or json.RawMessage holding JSON already
+type Responder func(ctx context.Context, call Call) (interface{}, error)
+
+// Option configures a fake Client
+type Option func(*Client)
+
+// WithoutFixtures makes calls without canned response fail with ErrNoResponse
+// instead of returning the default fixture of their endpoint
+func WithoutFixtures() Option {
+	return func(c *Client) {
+		c.fixtures = false
+	}
+}
+
+// WithClientOptions applies client options, e.g. pkg.WithMiddleware, to the underlying client
+func WithClientOptions(options ...pkg.ClientOption) Option {
+	return func(c *Client) {
+		c.clientOptions = append(c.clientOptions, options...)
+	}
+}
+
+// Client is a pkg.Client answering calls from memory
+type Client struct {
+	pkg.Client
+
+	fixtures      bool
+	clientOptions []pkg.ClientOption
+
+	mu         sync.Mutex
+	responders map[string]Responder
+	calls      []Call
+}
+
+var _ pkg.Client = (*Client)(nil)
+
+// NewClient creates a fake client
+func NewClient(options ...Option) *Client {
+	c := &Client{
+		fixtures:   true,
+		responders: make(map[string]Responder),
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	clientOptions := make([]pkg.ClientOption, 0, len(c.clientOptions)+3)
+	clientOptions = append(clientOptions, c.clientOptions...)
+	clientOptions = append(clientOptions,
+		// No request ever reaches the network
+		pkg.WithBaseURL("http://fakes.invalid"),
+		pkg.WithRetryPolicy(base.NoRetryPolicy()),
+		// Innermost, so that middlewares configured by the caller still apply
+		pkg.WithMiddleware(c.Middleware()),
+	)
+	c.Client = pkg.NewClient(clientOptions...)
+
+	return c
+}
+
+// SetResponse answers every call of operation with response
+func (c *Client) SetResponse(operation string, response interface{}) {
+	c.SetResponder(operation, func(context.Context, Call) (interface{}, error) {
+		return response, nil
+	})
+}
+
+// SetError answers every call of operation with err, e.g. an error returned by HTTPError
+func (c *Client) SetError(operation string, err error) {
+	c.SetResponder(operation, func(context.Context, Call) (interface{}, error) {
+		return nil, err
+	})
+}
+
+// SetResponder answers every call of operation with the outcome of responder
+func (c *Client) SetResponder(operation string, responder Responder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.responders[operation] = responder
+}
+
+// Calls returns every recorded call, in the order they were made
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Call(nil), c.calls...)
+}
+
+// CallsTo returns the recorded calls of operation
+func (c *Client) CallsTo(operation string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var calls []Call
+	for _, call := range c.calls {
+		if call.Operation == operation {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// CallCount returns the number of recorded calls of operation
+func (c *Client) CallCount(operation string) int {
+	return len(c.CallsTo(operation))
+}
+
+// Reset removes every recorded call and configured response
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = nil
+	c.responders = make(map[string]Responder)
+}
+
+// Middleware returns the middleware answering calls from memory, to be used with clients built elsewhere
+func (c *Client) Middleware() base.Middleware {
+	return func(next base.RoundTrip) base.RoundTrip {
+		return func(ctx context.Context, call *base.Call) (*base.Response, error) {
+			return c.roundTrip(ctx, call)
+		}
+	}
+}
+
+// roundTrip records a call and answers it
+func (c *Client) roundTrip(ctx context.Context, call *base.Call) (*base.Response, error) {
+	recorded := Call{
+		Operation:   call.Operation,
+		Method:      call.Method,
+		Path:        call.Path,
+		PathParams:  copyParams(call.PathParams),
+		QueryParams: copyParams(call.QueryParams),
+		Time:        time.Now(),
+	}
+
+	c.mu.Lock()
+	c.calls = append(c.calls, recorded)
+	responder, ok := c.responders[call.Operation]
+	c.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("request aborted: %w", err)
+	}
+
+	if !ok {
+		if body, found := coingeckotest.DefaultFixture(call.Path); c.fixtures && found {
+			return &base.Response{StatusCode: http.StatusOK, Body: body}, nil
+		}
+		return nil, fmt.Errorf("%w for %s", ErrNoResponse, call.Operation)
+	}
+
+	response, err := responder(ctx, recorded)
+	if err != nil {
+		// Complete API errors built by HTTPError with the call they answer
+		if apiErr, ok := err.(*base.APIError); ok && apiErr.Endpoint == "" {
+			completed := *apiErr
+			completed.Method = call.Method
+			completed.Endpoint = call.Path
+			return nil, &completed
+		}
+		return nil, err
+	}
+
+	body, err := encodeResponse(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &base.Response{StatusCode: http.StatusOK, Body: body}, nil
+}
+
+// HTTPError returns the error the client returns for a response with statusCode,
+// matched by helpers such as base.IsNotFound and base.IsRateLimited
+func HTTPError(statusCode int) error {
+	return &base.APIError{
+		StatusCode:   statusCode,
+		ErrorCode:    statusCode,
+		ErrorMessage: http.StatusText(statusCode),
+	}
+}
+
+// encodeResponse returns the JSON body of a canned response
+func encodeResponse(response interface{}) ([]byte, error) {
+	switch r := response.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return r, nil
+	case json.RawMessage:
+		return r, nil
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("fakes: failed to encode response: %w", err)
+	}
+	return body, nil
+}
+
+// copyParams returns a copy of path or query parameters
+func copyParams(params map[string]string) map[string]string {
+	if params == nil {
+		return nil
+	}
+
+	copied := make(map[string]string, len(params))
+	for name, value := range params {
+		copied[name] = value
+	}
+	return copied
+}
@@ -0,0 +1,320 @@
+package fakes_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ipangpang/coingecko-v3/pkg"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/asset_platforms"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/categories"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/coins"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/companies"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/contract"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/derivatives"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/exchange_rates"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/exchanges"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/global"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/key"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/nfts"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/onchain"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/ping"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/search"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/simple"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/trending"
+	"github.com/ipangpang/coingecko-v3/pkg/fakes"
+)
+
+const priceOperation = "simple.GetCoinPriceByIDs"
+
+var priceRequest = &simple.GetCoinPriceByIDsRequest{CoinIDs: []string{"bitcoin", "ethereum"}, VsCurrencies: []string{"usd"}}
+
+func TestResponsePriority(t *testing.T) {
+	canned := simple.GetCoinPriceByIDsResponse{"bitcoin": {USD: 1}}
+	responded := simple.GetCoinPriceByIDsResponse{"bitcoin": {USD: 2}}
+	notFound := fakes.HTTPError(http.StatusNotFound)
+	responder := func(ctx context.Context, call fakes.Call) (interface{}, error) {
+		return responded, nil
+	}
+
+	tests := []struct {
+		name      string
+		configure func(fake *fakes.Client)
+		wantPrice float64
+		wantErr   bool
+	}{
+		{
+			name:      "fixture by default",
+			configure: func(fake *fakes.Client) {},
+			wantPrice: -1,
+		},
+		{
+			name:      "response",
+			configure: func(fake *fakes.Client) { fake.SetResponse(priceOperation, canned) },
+			wantPrice: 1,
+		},
+		{
+			name:      "error",
+			configure: func(fake *fakes.Client) { fake.SetError(priceOperation, notFound) },
+			wantErr:   true,
+		},
+		{
+			name: "error replaces response",
+			configure: func(fake *fakes.Client) {
+				fake.SetResponse(priceOperation, canned)
+				fake.SetError(priceOperation, notFound)
+			},
+			wantErr: true,
+		},
+		{
+			name: "response replaces error",
+			configure: func(fake *fakes.Client) {
+				fake.SetError(priceOperation, notFound)
+				fake.SetResponse(priceOperation, canned)
+			},
+			wantPrice: 1,
+		},
+		{
+			name: "responder replaces response",
+			configure: func(fake *fakes.Client) {
+				fake.SetResponse(priceOperation, canned)
+				fake.SetResponder(priceOperation, responder)
+			},
+			wantPrice: 2,
+		},
+		{
+			name: "response replaces responder",
+			configure: func(fake *fakes.Client) {
+				fake.SetResponder(priceOperation, responder)
+				fake.SetResponse(priceOperation, canned)
+			},
+			wantPrice: 1,
+		},
+		{
+			name: "other operations keep their fixture",
+			configure: func(fake *fakes.Client) {
+				fake.SetError("simple.GetSupportedCurrencies", notFound)
+			},
+			wantPrice: -1,
+		},
+		{
+			name: "reset",
+			configure: func(fake *fakes.Client) {
+				fake.SetError(priceOperation, notFound)
+				fake.Reset()
+			},
+			wantPrice: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakes.NewClient()
+			tt.configure(fake)
+
+			response, err := fake.GetCoinPriceByIDs(priceRequest)
+			if tt.wantErr {
+				apiErr, ok := base.AsAPIError(err)
+				if !base.IsNotFound(err) || !ok || apiErr.Endpoint != simple.GetCoinPriceByIDsEndpoint {
+					t.Errorf("error = %#v, want a not found API error of %s", err, simple.GetCoinPriceByIDsEndpoint)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			price := (*response)["bitcoin"].USD
+			if tt.wantPrice < 0 {
+				// The default fixture holds a real price
+				if price <= 0 {
+					t.Errorf("price = %v, want the fixture price", price)
+				}
+				return
+			}
+			if price != tt.wantPrice {
+				t.Errorf("price = %v, want %v", price, tt.wantPrice)
+			}
+		})
+	}
+}
+
+func TestCallsRecordOperationAndParams(t *testing.T) {
+	fake := fakes.NewClient()
+
+	if _, err := fake.GetCoinPriceByIDs(priceRequest); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fake.GetCoinDataByID(&coins.GetCoinDataByIDRequest{ID: "bitcoin"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fake.GetCoinPriceByIDs(priceRequest); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("recorded %d calls, want 3", len(calls))
+	}
+
+	price := calls[0]
+	if price.Operation != priceOperation || price.Method != http.MethodGet || price.Path != simple.GetCoinPriceByIDsEndpoint {
+		t.Errorf("call = %+v", price)
+	}
+	if price.QueryParams["ids"] != "bitcoin,ethereum" || price.QueryParams["vs_currencies"] != "usd" {
+		t.Errorf("query = %v, want ids=bitcoin,ethereum and vs_currencies=usd", price.QueryParams)
+	}
+
+	coin := calls[1]
+	if coin.Operation != "coins.GetCoinDataByID" || coin.Path != coins.GetCoinDataByIDEndpoint || coin.PathParams["id"] != "bitcoin" {
+		t.Errorf("call = %+v", coin)
+	}
+
+	if got := fake.CallCount(priceOperation); got != 2 {
+		t.Errorf("CallCount = %d, want 2", got)
+	}
+	if got := len(fake.CallsTo("coins.GetCoinDataByID")); got != 1 {
+		t.Errorf("CallsTo returned %d calls, want 1", got)
+	}
+
+	// Invalid requests fail validation and are never recorded
+	if _, err := fake.GetCoinPriceByIDs(&simple.GetCoinPriceByIDsRequest{}); !base.IsValidationError(err) {
+		t.Errorf("error = %v, want a validation error", err)
+	}
+	if got := fake.CallCount(priceOperation); got != 2 {
+		t.Errorf("CallCount after an invalid request = %d, want 2", got)
+	}
+}
+
+func TestUnsetOperationWithoutFixtures(t *testing.T) {
+	var sent []string
+	fake := fakes.NewClient(
+		fakes.WithoutFixtures(),
+		fakes.WithClientOptions(pkg.WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			sent = append(sent, req.URL.String())
+			return nil, errors.New("network used")
+		}))),
+	)
+
+	_, err := fake.Ping()
+	if !errors.Is(err, fakes.ErrNoResponse) {
+		t.Fatalf("error = %v, want ErrNoResponse", err)
+	}
+	if !strings.Contains(err.Error(), "ping.Ping") {
+		t.Errorf("error %q does not name the operation", err)
+	}
+	if len(sent) != 0 {
+		t.Errorf("requests sent to the network: %v", sent)
+	}
+	if got := fake.CallCount("ping.Ping"); got != 1 {
+		t.Errorf("CallCount = %d, want 1", got)
+	}
+}
+
+// roundTripFunc is an http.RoundTripper calling itself
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAPIAccessors(t *testing.T) {
+	fake := fakes.NewClient()
+	ctx := context.Background()
+
+	// Each accessor satisfies its endpoint interface and answers through the fake
+	tests := []struct {
+		operation string
+		call      func() error
+	}{
+		{"ping.Ping", func() error { var api ping.Client = fake.PingAPI(); _, err := api.PingWithContext(ctx); return err }},
+		{"key.Key", func() error { var api key.Client = fake.KeyAPI(); _, err := api.KeyWithContext(ctx); return err }},
+		{"coins.GetCoinsList", func() error {
+			var api coins.Client = fake.CoinsAPI()
+			_, err := api.GetCoinsListWithContext(ctx, &coins.GetCoinsListRequest{})
+			return err
+		}},
+		{"simple.GetCoinPriceByIDs", func() error {
+			var api simple.Client = fake.SimpleAPI()
+			_, err := api.GetCoinPriceByIDsWithContext(ctx, priceRequest)
+			return err
+		}},
+		{"asset_platforms.GetAssetPlatforms", func() error {
+			var api asset_platforms.Client = fake.AssetPlatformsAPI()
+			_, err := api.GetAssetPlatformsWithContext(ctx)
+			return err
+		}},
+		{"categories.GetCategoriesList", func() error {
+			var api categories.Client = fake.CategoriesAPI()
+			_, err := api.GetCategoriesListWithContext(ctx)
+			return err
+		}},
+		{"exchanges.GetExchangesList", func() error {
+			var api exchanges.Client = fake.ExchangesAPI()
+			_, err := api.GetExchangesListWithContext(ctx, &exchanges.GetExchangesListRequest{})
+			return err
+		}},
+		{"contract.GetContractData", func() error {
+			var api contract.Client = fake.ContractAPI()
+			_, err := api.GetContractDataWithContext(ctx, &contract.GetContractDataRequest{
+				AssetPlatformID: "ethereum",
+				ContractAddress: "0xdac17f958d2ee523a2206206994597c13d831ec7",
+			})
+			return err
+		}},
+		{"derivatives.GetDerivativesList", func() error {
+			var api derivatives.Client = fake.DerivativesAPI()
+			_, err := api.GetDerivativesListWithContext(ctx)
+			return err
+		}},
+		{"nfts.GetNFTsList", func() error {
+			var api nfts.Client = fake.NFTsAPI()
+			_, err := api.GetNFTsListWithContext(ctx)
+			return err
+		}},
+		{"exchange_rates.GetExchangeRates", func() error {
+			var api exchange_rates.Client = fake.ExchangeRatesAPI()
+			_, err := api.GetExchangeRatesWithContext(ctx)
+			return err
+		}},
+		{"search.Search", func() error {
+			var api search.Client = fake.SearchAPI()
+			_, err := api.SearchWithContext(ctx, &search.SearchRequest{Query: "bitcoin"})
+			return err
+		}},
+		{"trending.GetTrending", func() error {
+			var api trending.Client = fake.TrendingAPI()
+			_, err := api.GetTrendingWithContext(ctx)
+			return err
+		}},
+		{"global.GetGlobal", func() error {
+			var api global.Client = fake.GlobalAPI()
+			_, err := api.GetGlobalWithContext(ctx)
+			return err
+		}},
+		{"companies.GetPublicTreasury", func() error {
+			var api companies.Client = fake.CompaniesAPI()
+			_, err := api.GetPublicTreasuryWithContext(ctx)
+			return err
+		}},
+		{"onchain.GetNetworks", func() error {
+			var api onchain.Client = fake.OnchainAPI()
+			_, err := api.GetNetworksWithContext(ctx, &onchain.GetNetworksRequest{})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			if err := tt.call(); err != nil {
+				t.Fatal(err)
+			}
+			if got := fake.CallCount(tt.operation); got != 1 {
+				t.Errorf("CallCount(%s) = %d, want 1", tt.operation, got)
+			}
+		})
+	}
+}
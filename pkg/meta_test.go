@@ -0,0 +1,37 @@
+package pkg_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ipangpang/coingecko-v3/pkg"
+	"github.com/ipangpang/coingecko-v3/pkg/coingeckotest"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/onchain"
+)
+
+func TestChunkedCallResponseMeta(t *testing.T) {
+	server := coingeckotest.NewServer()
+	t.Cleanup(server.Close)
+	client := pkg.NewClient(pkg.WithBaseURL(server.URL()), pkg.WithRetryPolicy(base.NoRetryPolicy()))
+
+	addresses := make([]string, onchain.MaxMultipleTokensAddresses+1)
+	for i := range addresses {
+		addresses[i] = fmt.Sprintf("0x%040x", i)
+	}
+
+	var meta base.ResponseMeta
+	ctx := base.WithResponseMeta(context.Background(), &meta)
+	_, err := client.OnchainAPI().GetMultipleTokensWithContext(ctx, &onchain.GetMultipleTokensRequest{Network: "eth", Addresses: addresses})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := server.TotalCalls(); got != 2 {
+		t.Fatalf("server received %d requests, want 2", got)
+	}
+	if meta.Operation != "onchain.GetMultipleTokens" || meta.Attempts != 2 || meta.Credits != 2 {
+		t.Errorf("meta = %+v, want the 2 chunk requests added up", meta)
+	}
+}
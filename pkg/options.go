@@ -0,0 +1,134 @@
+package pkg
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ClientOption defines client configuration options
+type ClientOption = base.ClientOption
+
+// ConfigFromEnv returns the default configuration updated from the
+// COINGECKO_API_KEY, COINGECKO_API_KEY_TYPE and COINGECKO_BASE_URL environment variables
+func ConfigFromEnv() *base.Config {
+	return base.ConfigFromEnv()
+}
+
+// WithBaseURL sets the base URL of the API
+func WithBaseURL(baseURL string) ClientOption {
+	return base.WithBaseURL(baseURL)
+}
+
+// WithTimeout sets the timeout of each request attempt
+func WithTimeout(timeout time.Duration) ClientOption {
+	return base.WithTimeout(timeout)
+}
+
+// WithRetry sets the number of retries and the wait duration before the first retry
+func WithRetry(count int, waitTime time.Duration) ClientOption {
+	return base.WithRetry(count, waitTime)
+}
+
+// WithRetryPolicy sets the retry policy
+func WithRetryPolicy(policy *base.RetryPolicy) ClientOption {
+	return base.WithRetryPolicy(policy)
+}
+
+// WithHTTPClient sets the HTTP client used to send requests
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return base.WithHTTPClient(httpClient)
+}
+
+// WithTransport sets the HTTP transport used to send requests
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return base.WithTransport(transport)
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return base.WithUserAgent(userAgent)
+}
+
+// WithProxy sends requests through the proxy at proxyURL
+func WithProxy(proxyURL string) ClientOption {
+	return base.WithProxy(proxyURL)
+}
+
+// WithAPIKey sets the API key, keeping the configured key type
+func WithAPIKey(apiKey string) ClientOption {
+	return base.WithAPIKey(apiKey)
+}
+
+// WithDemoAPIKey authenticates with a Demo plan key against the public host
+func WithDemoAPIKey(apiKey string) ClientOption {
+	return base.WithDemoAPIKey(apiKey)
+}
+
+// WithProAPIKey authenticates with a paid plan key against the Pro host
+func WithProAPIKey(apiKey string) ClientOption {
+	return base.WithProAPIKey(apiKey)
+}
+
+// WithAPIKeyInQuery authenticates with a key sent as a query parameter instead of a header
+func WithAPIKeyInQuery(apiKey string, keyType base.APIKeyType) ClientOption {
+	return base.WithAPIKeyInQuery(apiKey, keyType)
+}
+
+// WithAPIKeyPool authenticates requests with the keys of pool, all of the given type
+func WithAPIKeyPool(pool *base.APIKeyPool, keyType base.APIKeyType) ClientOption {
+	return base.WithAPIKeyPool(pool, keyType)
+}
+
+// WithRateLimit sets the maximum number of requests per minute and the limiter policy
+func WithRateLimit(requestsPerMinute int, policy base.RateLimitPolicy) ClientOption {
+	return base.WithRateLimit(requestsPerMinute, policy)
+}
+
+// WithCache stores GET responses in cache
+func WithCache(cache base.Cache) ClientOption {
+	return base.WithCache(cache)
+}
+
+// WithCacheTTL overrides the cache TTL of an endpoint path template
+func WithCacheTTL(path string, ttl time.Duration) ClientOption {
+	return base.WithCacheTTL(path, ttl)
+}
+
+// WithMiddleware appends middlewares to the chain wrapping every call
+func WithMiddleware(middlewares ...base.Middleware) ClientOption {
+	return base.WithMiddleware(middlewares...)
+}
+
+// WithTracerProvider creates an OpenTelemetry span for every call using tp
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return base.WithTracerProvider(tp)
+}
+
+// WithMetrics reports measurements of every call to metrics, e.g. a prommetrics.Metrics
+func WithMetrics(metrics base.Metrics) ClientOption {
+	return base.WithMetrics(metrics)
+}
+
+// WithLogger logs every request to logger with API keys redacted
+func WithLogger(logger *slog.Logger) ClientOption {
+	return base.WithLogger(logger)
+}
+
+// WithLogLevels sets the level of each kind of log record
+func WithLogLevels(levels base.LogLevels) ClientOption {
+	return base.WithLogLevels(levels)
+}
+
+// WithRequestCoalescing makes concurrent identical GET requests share a single upstream call
+func WithRequestCoalescing() ClientOption {
+	return base.WithRequestCoalescing()
+}
+
+// WithCircuitBreaker fails requests fast while an endpoint group keeps failing, according to policy
+func WithCircuitBreaker(policy *base.CircuitBreakerPolicy) ClientOption {
+	return base.WithCircuitBreaker(policy)
+}
@@ -0,0 +1,132 @@
+// Package prommetrics reports client metrics to Prometheus.
+//
+// Usage:
+//
+//	metrics, err := prommetrics.New(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client := pkg.NewClient(pkg.WithMetrics(metrics))
+//
+// Constant labels, e.g. a service name, can be added with prometheus.WrapRegistererWith.
+package prommetrics
+
+import (
+	"time"
+
+	"github.com/ipangpang/coingecko-v3/pkg/endpoints/base"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace is the prefix of every metric name
+const Namespace = "coingecko"
+
+// Metrics is a base.Metrics implementation backed by Prometheus collectors
+type Metrics struct {
+	requests    *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	retries     *prometheus.CounterVec
+	rateLimited *prometheus.CounterVec
+	cacheHits   *prometheus.CounterVec
+	cacheMisses *prometheus.CounterVec
+	credits     *prometheus.CounterVec
+}
+
+var _ base.Metrics = (*Metrics)(nil)
+
+// New creates the collectors and registers them with registerer.
+// A nil registerer leaves them unregistered.
+func New(registerer prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests sent to the API by endpoint and status class.",
+		}, []string{"endpoint", "status_class"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests sent to the API by endpoint.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "retries_total",
+			Help:      "Number of retried requests by endpoint.",
+		}, []string{"endpoint"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "rate_limited_total",
+			Help:      "Number of rate limited requests by endpoint and source (client or server).",
+		}, []string{"endpoint", "source"}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "cache_hits_total",
+			Help:      "Number of calls served from the cache by endpoint.",
+		}, []string{"endpoint"}),
+		cacheMisses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "cache_misses_total",
+			Help:      "Number of cacheable calls not served from the cache by endpoint.",
+		}, []string{"endpoint"}),
+		credits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "credits_total",
+			Help:      "Estimated number of API credits consumed by endpoint.",
+		}, []string{"endpoint"}),
+	}
+
+	if registerer != nil {
+		for _, collector := range m.Collectors() {
+			if err := registerer.Register(collector); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// Collectors returns the collectors of m, e.g. to register them manually
+func (m *Metrics) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.requests,
+		m.duration,
+		m.retries,
+		m.rateLimited,
+		m.cacheHits,
+		m.cacheMisses,
+		m.credits,
+	}
+}
+
+// ObserveRequest records an HTTP request with its status class and latency
+func (m *Metrics) ObserveRequest(endpoint, statusClass string, duration time.Duration) {
+	m.requests.WithLabelValues(endpoint, statusClass).Inc()
+	m.duration.WithLabelValues(endpoint).Observe(duration.Seconds())
+}
+
+// IncRetry records a retried attempt
+func (m *Metrics) IncRetry(endpoint string) {
+	m.retries.WithLabelValues(endpoint).Inc()
+}
+
+// IncRateLimited records a request rejected by the client-side limiter or by the API
+func (m *Metrics) IncRateLimited(endpoint, source string) {
+	m.rateLimited.WithLabelValues(endpoint, source).Inc()
+}
+
+// IncCacheHit records a call served from the cache
+func (m *Metrics) IncCacheHit(endpoint string) {
+	m.cacheHits.WithLabelValues(endpoint).Inc()
+}
+
+// IncCacheMiss records a cacheable call not served from the cache
+func (m *Metrics) IncCacheMiss(endpoint string) {
+	m.cacheMisses.WithLabelValues(endpoint).Inc()
+}
+
+// AddCredits records the estimated API credits consumed
+func (m *Metrics) AddCredits(endpoint string, credits float64) {
+	m.credits.WithLabelValues(endpoint).Add(credits)
+}